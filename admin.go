@@ -0,0 +1,147 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An AdminHandler is an http.Handler for inspecting and controlling a
+// Runner. It serves JSON at the following paths:
+//
+//	GET  /                 the Runner's Stats, as published by Publish
+//	GET  /jobs/{id}        one job, in the same form as in Stats
+//	POST /jobs/{id}/pause  pause the job (see Runner.Pause)
+//	POST /jobs/{id}/resume resume the job (see Runner.Resume)
+//	POST /jobs/{id}/run    run the job now (see Runner.RunNow)
+//	GET  /errors           the Runner's RecentErrors
+//
+// The POST requests respond with the job as it is afterwards. Errors are
+// reported as an object such as {"error": "no job 7"} with a suitable
+// status code.
+//
+// The paths are relative to where the handler is mounted, so to serve it
+// under a prefix, use http.StripPrefix:
+//
+//	mux.Handle("/cron/", http.StripPrefix("/cron", cron.NewAdminHandler(r)))
+//
+// An AdminHandler does no authentication; take care not to expose it to
+// untrusted clients.
+type AdminHandler struct {
+	r *Runner
+}
+
+// NewAdminHandler returns an AdminHandler for r.
+func NewAdminHandler(r *Runner) *AdminHandler {
+	return &AdminHandler{r: r}
+}
+
+type errorJSON struct {
+	ID    JobID  `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Time  string `json:"time"`
+	Error string `json:"error"`
+}
+
+func (h *AdminHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := strings.Trim(req.URL.Path, "/")
+	switch {
+	case path == "":
+		if checkMethod(w, req, http.MethodGet) {
+			writeJSON(w, http.StatusOK, h.r.Stats().expvarValue())
+		}
+	case path == "errors":
+		if checkMethod(w, req, http.MethodGet) {
+			errs := h.r.RecentErrors()
+			v := make([]errorJSON, len(errs))
+			for i, je := range errs {
+				v[i] = errorJSON{ID: je.ID, Name: je.Name, Time: je.Time.Format(time.RFC3339Nano), Error: je.Err.Error()}
+			}
+			writeJSON(w, http.StatusOK, v)
+		}
+	case strings.HasPrefix(path, "jobs/"):
+		h.serveJob(w, req, strings.Split(path, "/")[1:])
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+// serveJob serves the requests under /jobs/, whose remaining path elements
+// are elems.
+func (h *AdminHandler) serveJob(w http.ResponseWriter, req *http.Request, elems []string) {
+	if len(elems) > 2 {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	n, err := strconv.ParseUint(elems[0], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("invalid job ID %q", elems[0]))
+		return
+	}
+	id := JobID(n)
+	if len(elems) == 1 {
+		if checkMethod(w, req, http.MethodGet) {
+			h.writeJob(w, id)
+		}
+		return
+	}
+	var action func(JobID) bool
+	switch elems[1] {
+	case "pause":
+		action = h.r.Pause
+	case "resume":
+		action = h.r.Resume
+	case "run":
+		action = h.r.RunNow
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if !checkMethod(w, req, http.MethodPost) {
+		return
+	}
+	if !action(id) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no job %d", id))
+		return
+	}
+	h.writeJob(w, id)
+}
+
+// writeJob responds with the job with the given ID.
+func (h *AdminHandler) writeJob(w http.ResponseWriter, id JobID) {
+	for _, info := range h.r.Entries() {
+		if info.ID == id {
+			writeJSON(w, http.StatusOK, newJobJSON(info))
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("no job %d", id))
+}
+
+// checkMethod reports whether req has the given method, responding with an
+// error if not.
+func checkMethod(w http.ResponseWriter, req *http.Request, method string) bool {
+	if req.Method == method || method == http.MethodGet && req.Method == http.MethodHead {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	return false
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, struct {
+		Error string `json:"error"`
+	}{msg})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	enc.Encode(v)
+}
@@ -0,0 +1,97 @@
+package cron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAdminHandler(t *testing.T) {
+	r := NewRunner()
+	daily := r.AddFunc(mustParse(t, "0 3 * * *"), func() {}, WithName("daily"))
+	fail := r.Add(nil, func(context.Context) error { return errors.New("failed") })
+	h := NewAdminHandler(r)
+
+	do := func(method, path string, wantCode int) interface{} {
+		t.Helper()
+		req := httptest.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if w.Code != wantCode {
+			t.Errorf("%s %s: got status %d; want %d", method, path, w.Code, wantCode)
+		}
+		if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("%s %s: got Content-Type %q", method, path, ct)
+		}
+		var v interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &v); err != nil {
+			t.Fatalf("%s %s: %s", method, path, err)
+		}
+		return v
+	}
+
+	got := do("GET", "/", http.StatusOK)
+	want := map[string]interface{}{
+		"running": false,
+		"active":  float64(0),
+		"jobs": []interface{}{
+			map[string]interface{}{"id": float64(daily), "name": "daily", "recurrence": "0 3 * * *", "active": float64(0)},
+			map[string]interface{}{"id": float64(fail), "active": float64(0)},
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("GET / (-got, +want):\n%s", diff)
+	}
+
+	got = do("POST", "/jobs/1/pause", http.StatusOK)
+	if m, ok := got.(map[string]interface{}); !ok || m["paused"] != true {
+		t.Errorf("POST /jobs/1/pause: got %v", got)
+	}
+	if !r.Entries()[0].Paused {
+		t.Error("job not paused")
+	}
+	got = do("POST", "/jobs/1/resume", http.StatusOK)
+	if m, ok := got.(map[string]interface{}); !ok || m["paused"] != nil {
+		t.Errorf("POST /jobs/1/resume: got %v", got)
+	}
+
+	do("POST", "/jobs/2/run", http.StatusOK)
+	r.Wait(context.Background())
+	got = do("GET", "/errors", http.StatusOK)
+	errs, ok := got.([]interface{})
+	if !ok || len(errs) != 1 {
+		t.Fatalf("GET /errors: got %v", got)
+	}
+	if e := errs[0].(map[string]interface{}); e["id"] != float64(fail) || e["error"] != "failed" || e["time"] == nil {
+		t.Errorf("GET /errors: got %v", e)
+	}
+	got = do("GET", "/jobs/2", http.StatusOK)
+	if m, ok := got.(map[string]interface{}); !ok || m["prev"] == nil {
+		t.Errorf("GET /jobs/2: got %v", got)
+	}
+
+	for _, tt := range []struct {
+		method string
+		path   string
+		code   int
+		err    string
+	}{
+		{"GET", "/jobs/3", http.StatusNotFound, "no job 3"},
+		{"POST", "/jobs/3/run", http.StatusNotFound, "no job 3"},
+		{"GET", "/jobs/x", http.StatusNotFound, `invalid job ID "x"`},
+		{"GET", "/jobs/1/stop", http.StatusNotFound, "not found"},
+		{"GET", "/other", http.StatusNotFound, "not found"},
+		{"GET", "/jobs/1/run", http.StatusMethodNotAllowed, "method not allowed"},
+		{"DELETE", "/", http.StatusMethodNotAllowed, "method not allowed"},
+	} {
+		got := do(tt.method, tt.path, tt.code)
+		if diff := cmp.Diff(got, map[string]interface{}{"error": tt.err}); diff != "" {
+			t.Errorf("%s %s (-got, +want):\n%s", tt.method, tt.path, diff)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Builder constructs a Schedule from lists of field values without going
+// through a cron expression:
+//
+//	s, err := cron.NewBuilder().
+//		Minutes(0, 30).
+//		Hours(9, 17).
+//		Weekdays(cron.Monday, cron.Friday).
+//		Build()
+//
+// is equivalent to parsing "0,30 9,17 * * MON,FRI". Each method adds to the
+// values of its field; fields without values match every value, as with *.
+// Values use the same numbering as time.Time: days of the month start at 1.
+type Builder struct {
+	vals    [5][]int
+	present [5]bool
+}
+
+// NewBuilder returns a Builder for a schedule that fires every minute.
+func NewBuilder() *Builder {
+	return new(Builder)
+}
+
+// Minutes adds minutes (0-59) at which the schedule fires.
+func (b *Builder) Minutes(m ...int) *Builder { return b.add(0, m) }
+
+// Hours adds hours (0-23) at which the schedule fires.
+func (b *Builder) Hours(h ...int) *Builder { return b.add(1, h) }
+
+// DaysOfMonth adds days of the month (1-31) on which the schedule fires.
+func (b *Builder) DaysOfMonth(d ...int) *Builder { return b.add(2, d) }
+
+// Months adds months in which the schedule fires.
+func (b *Builder) Months(m ...time.Month) *Builder {
+	vals := make([]int, len(m))
+	for i, v := range m {
+		vals[i] = int(v)
+	}
+	return b.add(3, vals)
+}
+
+// Weekdays adds days of the week on which the schedule fires.
+func (b *Builder) Weekdays(d ...time.Weekday) *Builder {
+	vals := make([]int, len(d))
+	for i, v := range d {
+		vals[i] = int(v)
+	}
+	return b.add(4, vals)
+}
+
+func (b *Builder) add(fieldIndex int, vals []int) *Builder {
+	b.vals[fieldIndex] = append(b.vals[fieldIndex], vals...)
+	b.present[fieldIndex] = true
+	return b
+}
+
+// Build returns the Schedule. It returns an error if any value is out of
+// range for its field or if a field method was called without any values.
+func (b *Builder) Build() (Schedule, error) {
+	var s Schedule
+	for i, vals := range b.vals {
+		if !b.present[i] {
+			for j := 0; j < fieldSizes[i]; j++ {
+				s = s.set(fieldOffsets[i] + j)
+			}
+			continue
+		}
+		if len(vals) == 0 {
+			return Schedule{}, fmt.Errorf("no values given for the %s field", fieldNames[i])
+		}
+		for _, v := range vals {
+			j := v - fieldMin(i)
+			if j < 0 || j >= fieldSizes[i] {
+				return Schedule{}, fmt.Errorf("invalid value %d for the %s field", v, fieldNames[i])
+			}
+			s = s.set(fieldOffsets[i] + j)
+		}
+	}
+	return s, nil
+}
+
+// Every returns a Schedule that fires at regular intervals of d, aligned to
+// the start of the hour or day: for example, Every(15*time.Minute) is
+// equivalent to "*/15 * * * *" and Every(6*time.Hour) is equivalent to
+// "0 */6 * * *".
+//
+// Every returns an error unless d is a whole number of minutes that evenly
+// divides an hour, a whole number of hours that evenly divides a day, or
+// exactly one day. (Other intervals, such as 7 minutes or 5 hours, would
+// not be regular across hour and day boundaries.)
+func Every(d time.Duration) (Schedule, error) {
+	switch {
+	case d <= 0 || d%time.Minute != 0:
+	case d < time.Hour && time.Hour%d == 0:
+		return everyStep(0, int(d/time.Minute)).Build()
+	case d < 24*time.Hour && d%time.Hour == 0 && (24*time.Hour)%d == 0:
+		return everyStep(1, int(d/time.Hour)).Minutes(0).Build()
+	case d == 24*time.Hour:
+		return NewBuilder().Minutes(0).Hours(0).Build()
+	}
+	return Schedule{}, fmt.Errorf("cannot express interval %s as a cron schedule", d)
+}
+
+// everyStep returns a Builder whose field fieldIndex (which must be the
+// minute or hour field) contains every step'th value, starting at 0.
+func everyStep(fieldIndex, step int) *Builder {
+	var vals []int
+	for v := 0; v < fieldSizes[fieldIndex]; v += step {
+		vals = append(vals, v)
+	}
+	return NewBuilder().add(fieldIndex, vals)
+}
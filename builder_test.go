@@ -0,0 +1,90 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuilder(t *testing.T) {
+	for _, tt := range []struct {
+		b    *Builder
+		want string
+	}{
+		{NewBuilder(), "* * * * *"},
+		{NewBuilder().Minutes(0, 30).Hours(9, 17).Weekdays(Monday, Friday), "0,30 9,17 * * MON,FRI"},
+		{NewBuilder().Minutes(0).Minutes(15, 30, 45), "*/15 * * * *"},
+		{NewBuilder().Minutes(0).Hours(0).DaysOfMonth(1).Months(January, July), "0 0 1 JAN,JUL *"},
+		{NewBuilder().Minutes(59).Hours(23).DaysOfMonth(31).Months(December).Weekdays(Saturday), "59 23 31 DEC SAT"},
+	} {
+		s, err := tt.b.Build()
+		if err != nil {
+			t.Errorf("Build (want %q): %s", tt.want, err)
+			continue
+		}
+		if want := mustParse(t, tt.want); s != want {
+			t.Errorf("Build gave %q; want %q", s, tt.want)
+		}
+	}
+}
+
+func TestBuilderFail(t *testing.T) {
+	for _, tt := range []struct {
+		b    *Builder
+		want string // substring
+	}{
+		{NewBuilder().Minutes(60), "invalid value 60 for the minute field"},
+		{NewBuilder().Hours(-1), "invalid value -1 for the hour field"},
+		{NewBuilder().DaysOfMonth(0), "invalid value 0 for the day of month field"},
+		{NewBuilder().Months(13), "invalid value 13 for the month field"},
+		{NewBuilder().Weekdays(7), "invalid value 7 for the day of week field"},
+		{NewBuilder().Minutes(), "no values given for the minute field"},
+	} {
+		_, err := tt.b.Build()
+		if err == nil {
+			t.Errorf("Build succeeded; want error containing %q", tt.want)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Build: got error %q; want substring %q", err, tt.want)
+		}
+	}
+}
+
+func TestEvery(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string // empty if Every should fail
+	}{
+		{time.Minute, "* * * * *"},
+		{15 * time.Minute, "*/15 * * * *"},
+		{20 * time.Minute, "*/20 * * * *"},
+		{30 * time.Minute, "0,30 * * * *"},
+		{time.Hour, "0 * * * *"},
+		{6 * time.Hour, "0 */6 * * *"},
+		{12 * time.Hour, "0 0,12 * * *"},
+		{24 * time.Hour, "0 0 * * *"},
+		{0, ""},
+		{-time.Hour, ""},
+		{90 * time.Second, ""},
+		{7 * time.Minute, ""},
+		{90 * time.Minute, ""},
+		{5 * time.Hour, ""},
+		{48 * time.Hour, ""},
+	} {
+		s, err := Every(tt.d)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Every(%s) = %q; want error", tt.d, s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Every(%s): %s", tt.d, err)
+			continue
+		}
+		if want := mustParse(t, tt.want); s != want {
+			t.Errorf("Every(%s) = %q; want %q", tt.d, s, tt.want)
+		}
+	}
+}
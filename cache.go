@@ -0,0 +1,133 @@
+package cron
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// A ParseCache remembers the results of parsing cron expressions so that
+// parsing the same expression again is fast. It holds a bounded number of
+// results, discarding the least recently used when it is full. Errors are
+// cached along with Schedules.
+//
+// A ParseCache is safe for concurrent use.
+type ParseCache struct {
+	size int
+
+	mu      sync.Mutex
+	lru     *list.List // of *parseEntry, most recently used first
+	entries map[parseKey]*list.Element
+}
+
+type parseKey struct {
+	expr string
+	useH bool
+	seed uint64
+}
+
+type parseEntry struct {
+	key parseKey
+	s   Schedule
+	err error
+}
+
+// NewParseCache returns a ParseCache holding up to size results.
+// NewParseCache panics if size is not positive.
+func NewParseCache(size int) *ParseCache {
+	if size <= 0 {
+		panic("cron: non-positive size for NewParseCache")
+	}
+	return &ParseCache{
+		size:    size,
+		lru:     list.New(),
+		entries: make(map[parseKey]*list.Element),
+	}
+}
+
+// Parse is like the package-level Parse, but it uses the cache.
+func (c *ParseCache) Parse(expr string) (Schedule, error) {
+	return c.parse(parseKey{expr: expr}, func() (Schedule, error) { return Parse(expr) })
+}
+
+// ParseH is like the package-level ParseH, but it uses the cache.
+func (c *ParseCache) ParseH(expr string, seed uint64) (Schedule, error) {
+	key := parseKey{expr: expr, useH: true, seed: seed}
+	return c.parse(key, func() (Schedule, error) { return ParseH(expr, seed) })
+}
+
+func (c *ParseCache) parse(key parseKey, parse func() (Schedule, error)) (Schedule, error) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(e)
+		entry := e.Value.(*parseEntry)
+		c.mu.Unlock()
+		return entry.s, entry.err
+	}
+	c.mu.Unlock()
+
+	// Parse without holding the lock. Concurrent calls may parse the same
+	// expression more than once, but the results are the same.
+	s, err := parse()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok {
+		c.entries[key] = c.lru.PushFront(&parseEntry{key: key, s: s, err: err})
+		if c.lru.Len() > c.size {
+			oldest := c.lru.Back()
+			c.lru.Remove(oldest)
+			delete(c.entries, oldest.Value.(*parseEntry).key)
+		}
+	}
+	return s, err
+}
+
+// Len returns the number of results in c.
+func (c *ParseCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// A Memo is a Recurrence that remembers the last occurrence computed by
+// another Recurrence. Calling Next again with a time in the same location
+// between the previous argument and the occurrence it produced returns the
+// same occurrence without recomputing it, which makes frequent checks of
+// whether a schedule is due cheap. (A time in another location is
+// recomputed, since the occurrences of a Schedule depend on the wall clock
+// of the location.) Use NewMemo to create a Memo.
+//
+// A Memo is safe for concurrent use if its underlying Recurrence is.
+type Memo struct {
+	r Recurrence
+
+	mu       sync.Mutex
+	in, out  time.Time // in is the argument of the last call
+	computed bool
+}
+
+// NewMemo returns a Memo for r.
+func NewMemo(r Recurrence) *Memo {
+	return &Memo{r: r}
+}
+
+// Next returns the earliest occurrence of the underlying Recurrence after t.
+// If there is none (as for a Schedule that never fires), Next returns the
+// zero Time, and remembers it for later times too.
+func (m *Memo) Next(t time.Time) time.Time {
+	m.mu.Lock()
+	if m.computed && t.Location() == m.in.Location() && !t.Before(m.in) && (m.out.IsZero() || t.Before(m.out)) {
+		out := m.out
+		m.mu.Unlock()
+		return out
+	}
+	m.mu.Unlock()
+
+	out := m.r.Next(t)
+
+	m.mu.Lock()
+	m.in, m.out, m.computed = t, out, true
+	m.mu.Unlock()
+	return out
+}
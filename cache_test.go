@@ -0,0 +1,168 @@
+package cron
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseCache(t *testing.T) {
+	c := NewParseCache(2)
+	for _, expr := range []string{"0 * * * *", "0 0 * * *", "0 * * * *"} {
+		s, err := c.Parse(expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", expr, err)
+		}
+		if want := mustParse(t, expr); s != want {
+			t.Errorf("Parse(%q) = %q; want %q", expr, s, want)
+		}
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d; want 2", got)
+	}
+	// "0 0 * * *" is now the least recently used, so it is evicted.
+	c.Parse("*/5 * * * *")
+	if _, ok := c.entries[parseKey{expr: "0 0 * * *"}]; ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	if _, ok := c.entries[parseKey{expr: "0 * * * *"}]; !ok {
+		t.Error("recently used entry was evicted")
+	}
+
+	if _, err := c.Parse("* * *"); err == nil {
+		t.Error("Parse accepted an invalid expression")
+	}
+	if _, err := c.Parse("* * *"); err == nil {
+		t.Error("Parse accepted an invalid expression from the cache")
+	}
+
+	// Seeds are part of the key.
+	s1, err := c.ParseH("H H * * *", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s2, err := c.ParseH("H H * * *", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := ParseH("H H * * *", 1); s1 != want {
+		t.Errorf("ParseH with seed 1 = %q; want %q", s1, want)
+	}
+	if want, _ := ParseH("H H * * *", 2); s2 != want {
+		t.Errorf("ParseH with seed 2 = %q; want %q", s2, want)
+	}
+	if _, err := c.Parse("H H * * *"); err == nil {
+		t.Error("Parse accepted H after ParseH cached the expression")
+	}
+}
+
+func TestParseCacheConcurrent(t *testing.T) {
+	c := NewParseCache(10)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				expr := fmt.Sprintf("%d * * * *", (i+j)%20)
+				want, _ := Parse(expr)
+				if s, err := c.Parse(expr); err != nil || s != want {
+					t.Errorf("Parse(%q) = %q, %v; want %q", expr, s, err, want)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if got := c.Len(); got != 10 {
+		t.Errorf("Len() = %d; want 10", got)
+	}
+}
+
+// countingRecurrence counts calls to Next.
+type countingRecurrence struct {
+	r     Recurrence
+	calls int
+}
+
+func (c *countingRecurrence) Next(t time.Time) time.Time {
+	c.calls++
+	return c.r.Next(t)
+}
+
+func TestMemo(t *testing.T) {
+	cr := &countingRecurrence{r: mustParse(t, "0 * * * *")}
+	m := NewMemo(cr)
+	start := time.Date(2026, 1, 1, 0, 10, 0, 0, time.UTC)
+	want := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)
+	for d := time.Duration(0); d < 50*time.Minute; d += time.Minute {
+		if got := m.Next(start.Add(d)); !got.Equal(want) {
+			t.Fatalf("Next(%s) = %s; want %s", start.Add(d), got, want)
+		}
+	}
+	if cr.calls != 1 {
+		t.Errorf("underlying Next called %d times; want 1", cr.calls)
+	}
+
+	// Times outside the remembered range are recomputed.
+	for _, tt := range []struct {
+		t, want time.Time
+	}{
+		{want, want.Add(time.Hour)},
+		{start.Add(-time.Hour), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	} {
+		if got := m.Next(tt.t); !got.Equal(tt.want) {
+			t.Errorf("Next(%s) = %s; want %s", tt.t, got, tt.want)
+		}
+	}
+	if cr.calls != 3 {
+		t.Errorf("underlying Next called %d times; want 3", cr.calls)
+	}
+
+	// Results are in the argument's location.
+	ny := mustLoadLocation(t, "America/New_York")
+	if got := m.Next(start.Add(-time.Hour + time.Minute).In(ny)); got.Location() != ny {
+		t.Errorf("Next returned a time in %s; want %s", got.Location(), ny)
+	}
+
+	// A time in another location is recomputed, since the schedule's wall
+	// clock times are different instants there.
+	kolkata := mustLoadLocation(t, "Asia/Kolkata") // UTC+5:30
+	m = NewMemo(mustParse(t, "0 * * * *"))
+	for _, tt := range []struct {
+		t, want time.Time
+	}{
+		{start, time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{start.In(kolkata), time.Date(2026, 1, 1, 0, 30, 0, 0, time.UTC)},
+		{start, time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)},
+	} {
+		got := m.Next(tt.t)
+		if !got.Equal(tt.want) || got.Location() != tt.t.Location() {
+			t.Errorf("Next(%s) = %s; want %s", tt.t, got, tt.want.In(tt.t.Location()))
+		}
+	}
+
+	// A Recurrence with no more occurrences.
+	cr = &countingRecurrence{r: At(start)}
+	m = NewMemo(cr)
+	for i := 0; i < 3; i++ {
+		if got := m.Next(start.Add(time.Duration(i) * time.Hour)); !got.IsZero() {
+			t.Errorf("Next = %s; want zero Time", got)
+		}
+	}
+	if cr.calls != 1 {
+		t.Errorf("underlying Next called %d times; want 1", cr.calls)
+	}
+
+	// A Schedule which never fires.
+	cr = &countingRecurrence{r: mustParse(t, "0 0 30 2 *")}
+	m = NewMemo(cr)
+	for i := 0; i < 3; i++ {
+		if got := m.Next(start.AddDate(i, 0, 0)); !got.IsZero() {
+			t.Errorf("Next for a schedule that never fires = %s; want zero Time", got)
+		}
+	}
+	if cr.calls != 1 {
+		t.Errorf("underlying Next called %d times for a schedule that never fires; want 1", cr.calls)
+	}
+}
@@ -0,0 +1,169 @@
+package cron
+
+import "time"
+
+// A Calendar says which days are excluded from a schedule, such as public
+// holidays or company blackout dates. See ExceptCalendar.
+type Calendar interface {
+	// IsExcluded reports whether the day containing t (in t's location)
+	// is excluded.
+	IsExcluded(t time.Time) bool
+}
+
+// CalendarFunc adapts an ordinary function to the Calendar interface.
+type CalendarFunc func(t time.Time) bool
+
+// IsExcluded returns f(t).
+func (f CalendarFunc) IsExcluded(t time.Time) bool { return f(t) }
+
+// A DateSet is a Calendar that excludes a fixed set of dates. Use NewDateSet
+// to create a DateSet.
+type DateSet struct {
+	dates map[civilDay]bool
+}
+
+type civilDay struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+// NewDateSet returns a DateSet that excludes the date of each of dates (in
+// its own location). Only the year, month, and day of each time are used,
+// so the set excludes the same calendar days in every location.
+func NewDateSet(dates ...time.Time) DateSet {
+	ds := DateSet{dates: make(map[civilDay]bool)}
+	for _, t := range dates {
+		year, month, day := t.Date()
+		ds.dates[civilDay{year, month, day}] = true
+	}
+	return ds
+}
+
+// IsExcluded reports whether t's date is in ds.
+func (ds DateSet) IsExcluded(t time.Time) bool {
+	year, month, day := t.Date()
+	return ds.dates[civilDay{year, month, day}]
+}
+
+// CalendarExclusion is a Recurrence with the occurrences of another
+// Recurrence adjusted to avoid days excluded by a Calendar. Use
+// ExceptCalendar to create a CalendarExclusion.
+type CalendarExclusion struct {
+	r   Recurrence
+	cal Calendar
+	adj Adjustment
+}
+
+// ExceptCalendar returns a Recurrence with the occurrences of r except those
+// on days excluded by cal. For example,
+//
+//	cron.ExceptCalendar(daily, cron.NewDateSet(christmas, newYear))
+//
+// fires on the days daily does, except on the two holidays. To move excluded
+// occurrences to another day instead, use CalendarExclusion.WithAdjustment.
+func ExceptCalendar(r Recurrence, cal Calendar) CalendarExclusion {
+	return CalendarExclusion{r: r, cal: cal}
+}
+
+// An Adjustment says what a CalendarExclusion does with an occurrence on an
+// excluded day. The names follow the business day conventions used in
+// finance.
+type Adjustment int
+
+const (
+	// AdjustSkip drops the occurrence.
+	AdjustSkip Adjustment = iota
+	// AdjustFollowing moves the occurrence to the same wall clock time on
+	// the next day that isn't excluded.
+	AdjustFollowing
+	// AdjustPreceding moves the occurrence to the same wall clock time on
+	// the previous day that isn't excluded.
+	AdjustPreceding
+)
+
+// maxAdjustDays is the furthest an Adjustment moves an occurrence. An
+// occurrence with no day that isn't excluded within this many days is
+// dropped.
+const maxAdjustDays = 31
+
+// WithAdjustment returns a copy of c that handles occurrences on excluded
+// days according to a. If several occurrences are moved to the same time, or
+// an occurrence is moved to the time of another, c fires only once at that
+// time.
+func (c CalendarExclusion) WithAdjustment(a Adjustment) CalendarExclusion {
+	c.adj = a
+	return c
+}
+
+// Next returns the earliest occurrence of c after t. It consults the
+// Calendar for each occurrence of the underlying Recurrence in turn, so it
+// does not return if the Calendar excludes every one.
+func (c CalendarExclusion) Next(t time.Time) time.Time {
+	if c.adj == AdjustSkip {
+		for {
+			next := c.r.Next(t)
+			if next.IsZero() || !c.cal.IsExcluded(next) {
+				return next
+			}
+			t = next
+		}
+	}
+
+	start := t
+	if c.adj == AdjustFollowing {
+		// Occurrences before t may be moved after it if they fall in a
+		// run of excluded days leading up to t's day.
+		year, month, day := t.Date()
+		start = time.Date(year, month, day, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+		for k := 1; k <= maxAdjustDays; k++ {
+			prev := time.Date(year, month, day-k, 0, 0, 0, 0, t.Location())
+			if !c.cal.IsExcluded(prev) {
+				break
+			}
+			start = prev.Add(-time.Nanosecond)
+		}
+	}
+	// Moved occurrences can be out of order, but the days they are moved
+	// to are in order, so the search can stop once it passes the day of
+	// the earliest candidate.
+	var best time.Time
+	for occ := c.r.Next(start); !occ.IsZero(); occ = c.r.Next(occ) {
+		adjusted, ok := c.adjust(occ)
+		if !ok {
+			continue
+		}
+		if !best.IsZero() && civilDays(adjusted) > civilDays(best) {
+			break
+		}
+		if adjusted.After(t) && (best.IsZero() || adjusted.Before(best)) {
+			best = adjusted
+		}
+	}
+	return best
+}
+
+// adjust applies c's Adjustment to the occurrence t. It reports false if t
+// is dropped.
+func (c CalendarExclusion) adjust(t time.Time) (time.Time, bool) {
+	if !c.cal.IsExcluded(t) {
+		return t, true
+	}
+	dir := 1
+	switch c.adj {
+	case AdjustFollowing:
+	case AdjustPreceding:
+		dir = -1
+	default:
+		return time.Time{}, false
+	}
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	for k := 1; k <= maxAdjustDays; k++ {
+		t1 := time.Date(year, month, day+dir*k, hour, min, sec, t.Nanosecond(), t.Location())
+		if !c.cal.IsExcluded(t1) {
+			return t1, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,124 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	_ Calendar   = CalendarFunc(nil)
+	_ Calendar   = DateSet{}
+	_ Recurrence = CalendarExclusion{}
+)
+
+func TestExceptCalendar(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	holidays := NewDateSet(
+		time.Date(2026, 12, 25, 0, 0, 0, 0, time.UTC),
+		time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+	)
+	c := ExceptCalendar(mustParse(t, "0 9 * * MON-FRI"), holidays)
+	tm := time.Date(2026, 12, 23, 12, 0, 0, 0, ny)
+	var got []time.Time
+	for i := 0; i < 4; i++ {
+		tm = c.Next(tm)
+		got = append(got, tm)
+	}
+	want := []time.Time{
+		time.Date(2026, 12, 24, 9, 0, 0, 0, ny),
+		time.Date(2026, 12, 28, 9, 0, 0, 0, ny),
+		time.Date(2026, 12, 29, 9, 0, 0, 0, ny),
+		time.Date(2026, 12, 30, 9, 0, 0, 0, ny),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("ExceptCalendar (-got, +want):\n%s", diff)
+	}
+
+	weekends := CalendarFunc(func(t time.Time) bool {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	})
+	c = ExceptCalendar(mustParse(t, "0 0 1 * *"), weekends)
+	tm = time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	// February 1 and March 1, 2026 are Sundays.
+	if got, want := c.Next(tm), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next(%s) = %s; want %s", tm, got, want)
+	}
+
+	// The zero Time from the underlying Recurrence is passed through.
+	c = ExceptCalendar(At(tm), weekends)
+	if got := c.Next(tm); !got.IsZero() {
+		t.Errorf("Next(%s) = %s; want zero Time", tm, got)
+	}
+}
+
+func TestCalendarAdjustment(t *testing.T) {
+	weekends := CalendarFunc(func(t time.Time) bool {
+		return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+	})
+	date := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	for _, tt := range []struct {
+		expr string
+		adj  Adjustment
+		t    time.Time
+		want []time.Time
+	}{
+		{
+			// February 1 and March 1 are Sundays.
+			"0 9 1 * *",
+			AdjustFollowing,
+			date(1, 15, 0),
+			[]time.Time{date(2, 2, 9), date(3, 2, 9), date(4, 1, 9)},
+		},
+		{
+			"0 9 1 * *",
+			AdjustPreceding,
+			date(1, 15, 0),
+			[]time.Time{date(1, 30, 9), date(2, 27, 9), date(4, 1, 9)},
+		},
+		{
+			"0 9 1 * *",
+			AdjustSkip,
+			date(1, 15, 0),
+			[]time.Time{date(4, 1, 9), date(5, 1, 9)},
+		},
+		{
+			// Saturday and Sunday's occurrences, including one before
+			// t, move to Monday, where they coincide with Monday's
+			// own occurrences.
+			"0 9,17 * * *",
+			AdjustFollowing,
+			date(1, 3, 18), // Saturday
+			[]time.Time{date(1, 5, 9), date(1, 5, 17), date(1, 6, 9)},
+		},
+		{
+			// Friday's occurrences are followed by the weekend's,
+			// moved back to Friday.
+			"0 9,17 * * *",
+			AdjustPreceding,
+			date(1, 2, 8), // Friday
+			[]time.Time{date(1, 2, 9), date(1, 2, 17), date(1, 5, 9)},
+		},
+		{
+			// The weekend's 09:00 occurrences move back before t.
+			"0 9,17 * * *",
+			AdjustPreceding,
+			date(1, 2, 10),
+			[]time.Time{date(1, 2, 17), date(1, 5, 9)},
+		},
+	} {
+		c := ExceptCalendar(mustParse(t, tt.expr), weekends).WithAdjustment(tt.adj)
+		var got []time.Time
+		tm := tt.t
+		for range tt.want {
+			tm = c.Next(tm)
+			got = append(got, tm)
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("Parse(%q) with adjustment %d from %s (-got, +want):\n%s", tt.expr, tt.adj, tt.t, diff)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A DivergenceKind classifies a Divergence.
+type DivergenceKind int
+
+const (
+	// DivergenceMeaning means that Parse and Vixie cron both accept the
+	// expression but fire at different times.
+	DivergenceMeaning DivergenceKind = iota + 1
+	// DivergenceRejected means that Vixie cron accepts the construct but
+	// Parse rejects it.
+	DivergenceRejected
+	// DivergenceVixieRejects means that Parse accepts the construct but
+	// Vixie cron rejects or ignores it.
+	DivergenceVixieRejects
+	// DivergenceNotPOSIX means that Parse and Vixie cron agree, but the
+	// construct is an extension to the POSIX crontab format, which has
+	// neither names nor steps.
+	DivergenceNotPOSIX
+)
+
+var divergenceKindNames = [...]string{
+	DivergenceMeaning:      "meaning",
+	DivergenceRejected:     "rejected",
+	DivergenceVixieRejects: "rejected by Vixie cron",
+	DivergenceNotPOSIX:     "not POSIX",
+}
+
+func (k DivergenceKind) String() string {
+	if k < DivergenceMeaning || int(k) >= len(divergenceKindNames) {
+		return fmt.Sprintf("DivergenceKind(%d)", int(k))
+	}
+	return divergenceKindNames[k]
+}
+
+// A Divergence describes part of a cron expression which this package
+// interprets differently from classic cron implementations. Divergences are
+// returned by CompareClassic.
+type Divergence struct {
+	Kind DivergenceKind
+	// Field is the name of the field containing the construct, such as
+	// "day of week", or "" if it concerns the whole expression.
+	Field string
+	// Part is the text of the construct, such as "7".
+	Part    string
+	Message string
+}
+
+func (d Divergence) String() string {
+	if d.Field == "" {
+		return fmt.Sprintf("%s: %q: %s", d.Kind, d.Part, d.Message)
+	}
+	return fmt.Sprintf("%s: %s field %q: %s", d.Kind, d.Field, d.Part, d.Message)
+}
+
+// CompareClassic reports the ways in which Parse's interpretation of expr
+// differs from that of Vixie cron (the cron of most Linux and BSD systems)
+// and of the POSIX crontab format, in the order of the fields. These are:
+//
+//   - A schedule which restricts both the day of the month and the day of
+//     the week (that is, neither field begins with *) fires only on days
+//     matching both fields, but Vixie cron runs it on days matching either.
+//   - Vixie cron accepts 7 as a day of the week, meaning Sunday; ranges
+//     whose start and end are the same, such as 5-5; and the named schedules
+//     "@yearly", "@annually", and "@midnight" (which ParseCrontab accepts).
+//   - Parse accepts names of months and days of the week abbreviated to
+//     any unique prefix, or not abbreviated, but Vixie cron accepts only
+//     three-letter abbreviations.
+//   - Parse accepts ranges which wrap around the end of the field, such as
+//     22-2 for hours, from which Vixie cron selects nothing.
+//   - Names, steps, and named schedules are extensions to the POSIX format.
+//
+// CompareClassic returns an error if expr is invalid for a reason other than
+// those listed.
+func CompareClassic(expr string) ([]Divergence, error) {
+	if strings.HasPrefix(expr, "@") {
+		if _, ok := crontabSchedules[expr]; ok {
+			return []Divergence{{DivergenceRejected, "", expr, "Vixie cron accepts this named schedule, but Parse does not"}}, nil
+		}
+		if _, err := Parse(expr); err != nil {
+			return nil, err
+		}
+		return []Divergence{{DivergenceNotPOSIX, "", expr, "named schedules are not part of the POSIX format"}}, nil
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		_, err := Parse(expr)
+		return nil, err
+	}
+	var divs []Divergence
+	// fixed is expr with the constructs which Parse rejects rewritten to
+	// have the same meaning as in Vixie cron, so that it parses unless
+	// expr is invalid in some other way.
+	fixed := make([]string, len(fields))
+	for i, field := range fields {
+		parts := strings.Split(field, ",")
+		for j, part := range parts {
+			var d []Divergence
+			d, parts[j] = comparePart(part, i)
+			divs = append(divs, d...)
+		}
+		fixed[i] = strings.Join(parts, ",")
+	}
+	if _, err := Parse(strings.Join(fixed, " ")); err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*") {
+		divs = append(divs, Divergence{DivergenceMeaning, "", expr,
+			"both the day of month and the day of week are restricted: " +
+				"the schedule fires on days matching both, but Vixie cron runs it on days matching either"})
+	}
+	return divs, nil
+}
+
+// comparePart returns the divergences in part, an element of a list in the
+// field with the given index, and part rewritten, if needed, to be accepted
+// by Parse.
+func comparePart(part string, fieldIndex int) (divs []Divergence, fixed string) {
+	add := func(kind DivergenceKind, text, format string, args ...interface{}) {
+		divs = append(divs, Divergence{kind, fieldNames[fieldIndex], text, fmt.Sprintf(format, args...)})
+	}
+	base, step := part, ""
+	if i := strings.Index(part, "/"); i >= 0 {
+		base, step = part[:i], part[i:]
+		add(DivergenceNotPOSIX, part, "steps are not part of the POSIX format")
+	}
+	if base == "*" {
+		return divs, part
+	}
+	bounds := strings.SplitN(base, "-", 2)
+	vals := make([]int, len(bounds))
+	for j, b := range bounds {
+		vals[j] = -1
+		if n, err := strconv.Atoi(b); err == nil {
+			vals[j] = n
+			if fieldIndex == 4 && n == 7 {
+				add(DivergenceRejected, b, "Vixie cron accepts 7 as Sunday, but Parse accepts only 0-6")
+				bounds[j] = "0"
+			}
+			continue
+		}
+		if n, err := parseSingleValue(b, fieldIndex); err == nil {
+			vals[j] = n
+			if len(b) == 3 {
+				add(DivergenceNotPOSIX, b, "names are not part of the POSIX format")
+			} else {
+				add(DivergenceVixieRejects, b, "Vixie cron accepts only three-letter names")
+			}
+		}
+	}
+	if len(vals) == 2 && vals[0] >= 0 && vals[1] >= 0 {
+		switch {
+		case vals[0] == vals[1]:
+			add(DivergenceRejected, base, "Vixie cron accepts a range with the same start and end, but Parse does not")
+			bounds = bounds[:1]
+		case vals[0] > vals[1]:
+			add(DivergenceVixieRejects, base, "the range wraps around the end of the field, but Vixie cron selects nothing from it")
+		}
+	}
+	return divs, strings.Join(bounds, "-") + step
+}
@@ -0,0 +1,68 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompareClassic(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want []string
+	}{
+		{"0 3 * * *", nil},
+		{"0 0 1 * MON", []string{
+			`not POSIX: day of week field "MON": names are not part of the POSIX format`,
+			`meaning: "0 0 1 * MON": both the day of month and the day of week are restricted: ` +
+				`the schedule fires on days matching both, but Vixie cron runs it on days matching either`,
+		}},
+		{"0 0 * * 5-7", []string{
+			`rejected: day of week field "7": Vixie cron accepts 7 as Sunday, but Parse accepts only 0-6`,
+		}},
+		{"0 0 * * monday", []string{
+			`rejected by Vixie cron: day of week field "monday": Vixie cron accepts only three-letter names`,
+		}},
+		{"0 22-2 * * *", []string{
+			`rejected by Vixie cron: hour field "22-2": the range wraps around the end of the field, but Vixie cron selects nothing from it`,
+		}},
+		{"5-5 * * * *", []string{
+			`rejected: minute field "5-5": Vixie cron accepts a range with the same start and end, but Parse does not`,
+		}},
+		{"*/5 * * * *", []string{
+			`not POSIX: minute field "*/5": steps are not part of the POSIX format`,
+		}},
+		{"@yearly", []string{
+			`rejected: "@yearly": Vixie cron accepts this named schedule, but Parse does not`,
+		}},
+		{"@daily", []string{
+			`not POSIX: "@daily": named schedules are not part of the POSIX format`,
+		}},
+	} {
+		divs, err := CompareClassic(tt.expr)
+		if err != nil {
+			t.Errorf("CompareClassic(%q): %s", tt.expr, err)
+			continue
+		}
+		var got []string
+		for _, d := range divs {
+			got = append(got, d.String())
+		}
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("CompareClassic(%q): (-want, +got)\n%s", tt.expr, diff)
+		}
+	}
+}
+
+func TestCompareClassicError(t *testing.T) {
+	for _, expr := range []string{
+		"61 * * * *",
+		"0 3 * * 7,XYZ",
+		"* * * *",
+		"@often",
+	} {
+		if divs, err := CompareClassic(expr); err == nil {
+			t.Errorf("CompareClassic(%q) = %v; want error", expr, divs)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cron
+
+import "time"
+
+// A Clock tells the time and makes timers. A Runner uses a Clock to decide
+// when to run jobs, so that tests can substitute a Clock which they control
+// (see WithClock).
+type Clock interface {
+	Now() time.Time
+	// NewTimer returns a Timer which sends the current time on its channel
+	// once d has elapsed.
+	NewTimer(d time.Duration) Timer
+}
+
+// A Timer is a single event created by a Clock, like a time.Timer.
+type Timer interface {
+	// C returns the channel on which the time is sent when the Timer
+	// fires.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing. It reports whether it stopped
+	// the Timer, as opposed to the Timer having already fired or been
+	// stopped.
+	Stop() bool
+}
+
+// SystemClock is the Clock given by the time package. It is the default
+// Clock of a Runner.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time { return t.t.C }
+func (t systemTimer) Stop() bool          { return t.t.Stop() }
@@ -0,0 +1,120 @@
+package cron
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock whose time changes only when advance is called.
+type manualClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*manualTimer
+}
+
+type manualTimer struct {
+	c       chan time.Time
+	when    time.Time
+	clock   *manualClock
+	stopped bool
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) NewTimer(d time.Duration) Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTimer{c: make(chan time.Time, 1), when: c.now.Add(d), clock: c}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+func (t *manualTimer) C() <-chan time.Time { return t.c }
+
+func (t *manualTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := !t.stopped
+	t.stopped = true
+	return wasActive
+}
+
+// advance moves the time forward by d and fires the timers due by then.
+func (c *manualClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		if !t.stopped && !t.when.After(c.now) {
+			t.stopped = true
+			t.c <- c.now
+		}
+	}
+}
+
+// jump moves the time forward by d without firing any timers, as when the
+// wall clock is set forward: timers measure elapsed time, so they come due
+// d later too.
+func (c *manualClock) jump(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.timers {
+		t.when = t.when.Add(d)
+	}
+}
+
+// pending reports the number of timers waiting to fire.
+func (c *manualClock) pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, t := range c.timers {
+		if !t.stopped {
+			n++
+		}
+	}
+	return n
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	now := SystemClock.Now()
+	if now.Before(before) || now.After(time.Now()) {
+		t.Errorf("SystemClock.Now() = %s; want about %s", now, before)
+	}
+	timer := SystemClock.NewTimer(time.Millisecond)
+	if fired := <-timer.C(); fired.Before(before) {
+		t.Errorf("timer fired at %s, before it was created", fired)
+	}
+	if timer.Stop() {
+		t.Error("Stop() after firing = true")
+	}
+	if !SystemClock.NewTimer(time.Hour).Stop() {
+		t.Error("Stop() before firing = false")
+	}
+}
+
+func TestRunnerClock(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := NewRunner(WithClock(clock))
+	var runs int32
+	r.AddFunc(mustParse(t, "0 * * * *"), func() { atomic.AddInt32(&runs, 1) })
+	r.Start()
+	defer r.Stop()
+	for i := 1; i <= 3; i++ {
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.advance(time.Hour)
+		want := int32(i)
+		waitFor(t, "hourly run", func() bool { return atomic.LoadInt32(&runs) == want })
+	}
+	if got, want := r.Entries()[0].Prev, time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Prev = %s; want %s", got, want)
+	}
+}
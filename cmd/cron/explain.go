@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cespare/cron"
+)
+
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("explain", "<expr>", stderr)
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	s, err := cron.Parse(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "cron explain: %s\n", err)
+		return 1
+	}
+	var b strings.Builder
+	b.WriteString(cron.Describe(s) + "\n\n")
+	for _, f := range []struct {
+		name   string
+		values func() ([]int, bool)
+		label  func(int) string
+	}{
+		{"minute", s.Minutes, nil},
+		{"hour", s.Hours, nil},
+		{"day of month", s.DaysOfMonth, nil},
+		{"month", s.Months, func(v int) string { return time.Month(v).String()[:3] }},
+		{"day of week", s.Weekdays, func(v int) string { return time.Weekday(v).String()[:3] }},
+	} {
+		fmt.Fprintf(&b, "%-14s %s\n", f.name+":", formatValues(f.values, f.label))
+	}
+	io.WriteString(stdout, b.String())
+	return 0
+}
+
+// formatValues formats the values of a field, as returned by values, as
+// "*" if they are all of the field's values and otherwise as a list,
+// followed by the values' names if label is not nil.
+func formatValues(values func() ([]int, bool), label func(int) string) string {
+	vals, all := values()
+	if all {
+		return "*"
+	}
+	nums := make([]string, len(vals))
+	var names []string
+	for i, v := range vals {
+		nums[i] = strconv.Itoa(v)
+		if label != nil {
+			names = append(names, strings.ToUpper(label(v)))
+		}
+	}
+	s := strings.Join(nums, ",")
+	if names != nil {
+		s += " (" + strings.Join(names, ",") + ")"
+	}
+	return s
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/cespare/cron"
+)
+
+func runHash(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("hash", "-key key | -seed seed <expr>", stderr)
+	key := fs.String("key", "", "choose the values of H using the seed derived from `key`, such as a job or host name")
+	seed := fs.Uint64("seed", 0, "choose the values of H using `seed`")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	var haveKey, haveSeed bool
+	fs.Visit(func(f *flag.Flag) {
+		haveKey = haveKey || f.Name == "key"
+		haveSeed = haveSeed || f.Name == "seed"
+	})
+	if fs.NArg() != 1 || haveKey == haveSeed {
+		fs.Usage()
+		return 2
+	}
+	if haveKey {
+		*seed = cron.KeySeed(*key)
+	}
+	s, err := cron.ParseH(fs.Arg(0), *seed)
+	if err != nil {
+		fmt.Fprintf(stderr, "cron hash: %s\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, s)
+	return 0
+}
@@ -0,0 +1,103 @@
+// Command cron inspects cron schedules using the semantics of the
+// github.com/cespare/cron package, for use in shell scripts and CI checks.
+//
+// Usage:
+//
+//	cron <command> [flags] [arguments]
+//
+// The commands are:
+//
+//	explain    describe a schedule and list the values of its fields
+//	hash       resolve the H symbols of an expression for a key
+//	next       print the times at which a schedule next fires
+//	translate  convert an expression between cron dialects
+//	validate   check cron expressions or crontab files
+//
+// Run "cron <command> -h" for a command's flags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// A command is a subcommand of cron. It returns the exit status.
+type command struct {
+	summary string
+	run     func(args []string, stdout, stderr io.Writer) int
+}
+
+var commands = map[string]command{
+	"explain":   {"describe a schedule and list the values of its fields", runExplain},
+	"hash":      {"resolve the H symbols of an expression for a key", runHash},
+	"next":      {"print the times at which a schedule next fires", runNext},
+	"translate": {"convert an expression between cron dialects", runTranslate},
+	"validate":  {"check cron expressions or crontab files", runValidate},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+	switch args[0] {
+	case "-h", "-help", "--help", "help":
+		usage(stdout)
+		return 0
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "cron: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+	return cmd.run(args[1:], stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("usage: cron <command> [flags] [arguments]\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "  %-10s %s\n", name, commands[name].summary)
+	}
+	b.WriteString("\nRun 'cron <command> -h' for a command's flags.\n")
+	io.WriteString(w, b.String())
+}
+
+// newFlagSet returns a FlagSet for the named command which writes its
+// errors and usage, which begins with the given synopsis, to stderr.
+func newFlagSet(name, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet("cron "+name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: cron %s %s\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args with fs, returning the exit status and false if
+// the command should stop.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	switch err := fs.Parse(args); err {
+	case nil:
+		return 0, true
+	case flag.ErrHelp:
+		return 0, false
+	default:
+		return 2, false
+	}
+}
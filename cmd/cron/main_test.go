@@ -0,0 +1,173 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	// Without -tz, next uses the local time zone.
+	defer func(loc *time.Location) { time.Local = loc }(time.Local)
+	time.Local = time.UTC
+
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	good := filepath.Join(dir, "good")
+	bad := filepath.Join(dir, "bad")
+	if err := ioutil.WriteFile(good, []byte("# m h dom mon dow command\n0 3 * * * backup\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(bad, []byte("0 3 * * * backup\n0 9 * * * date +%F\n61 * * * * check\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		args   []string
+		status int
+		stdout string
+		stderr string // a substring of the standard error
+	}{
+		{
+			args:   []string{"next", "-n", "3", "-from", "2026-01-01T00:00:00Z", "0 3 * * WED"},
+			stdout: "2026-01-07T03:00:00Z\n2026-01-14T03:00:00Z\n2026-01-21T03:00:00Z\n",
+		},
+		{
+			args:   []string{"next", "-n", "2", "-from", "2026-01-01T00:00:00Z", "-tz", "America/New_York", "@daily"},
+			stdout: "2026-01-01T00:00:00-05:00\n2026-01-02T00:00:00-05:00\n",
+		},
+		{
+			args:   []string{"next", "-n", "1", "-from", "2026-01-01T00:00:00+09:00", "0 3 * * *"},
+			stdout: "2026-01-01T03:00:00Z\n",
+		},
+		{
+			args:   []string{"next", "-n", "1", "-from", "2026-01-01T00:00:00+09:00", "-tz", "America/New_York", "0 3 * * *"},
+			stdout: "2026-01-01T03:00:00-05:00\n",
+		},
+		{
+			args:   []string{"next", "0 0 30 2 *"},
+			status: 1,
+			stderr: "schedule never fires",
+		},
+		{
+			args:   []string{"next", "0 3 * * XYZ"},
+			status: 1,
+			stderr: "cron next: ",
+		},
+		{
+			args:   []string{"next", "-from", "yesterday", "@daily"},
+			status: 2,
+			stderr: "invalid -from time",
+		},
+		{
+			args:   []string{"next"},
+			status: 2,
+			stderr: "usage: cron next",
+		},
+		{
+			args: []string{"explain", "*/15 9-17 * 1,4 MON-FRI"},
+			stdout: "Every 15 minutes during hours 9 through 17 on Monday through Friday in January and April\n\n" +
+				"minute:        0,15,30,45\n" +
+				"hour:          9,10,11,12,13,14,15,16,17\n" +
+				"day of month:  *\n" +
+				"month:         1,4 (JAN,APR)\n" +
+				"day of week:   1,2,3,4,5 (MON,TUE,WED,THU,FRI)\n",
+		},
+		{
+			args:   []string{"explain", "0 3 * *"},
+			status: 1,
+			stderr: "cron explain: ",
+		},
+		{
+			args:   []string{"translate", "--from", "quartz", "--to", "standard", "0 30 2 ? * MON-FRI"},
+			stdout: "30 2 * * MON-FRI\n",
+		},
+		{
+			args:   []string{"translate", "-to", "AWS", "0 3 * * MON"},
+			stdout: "0 3 ? * MON *\n",
+		},
+		{
+			args:   []string{"translate", "-to", "aws", "0 3 1 * MON"},
+			status: 1,
+			stderr: "both the day of month and the day of week are restricted",
+		},
+		{
+			args:   []string{"translate", "61 3 * * *"},
+			status: 1,
+			stderr: "cron translate: ",
+		},
+		{
+			args:   []string{"translate", "-from", "cobol", "0 3 * * *"},
+			status: 2,
+			stderr: "unknown dialect",
+		},
+		{
+			args:   []string{"hash", "--key", "my-job-name", "H H * * *"},
+			stdout: "15 23 * * *\n",
+		},
+		{
+			args:   []string{"hash", "-seed", "7", "@weekly"},
+			stdout: "26 6 * * TUE\n",
+		},
+		{
+			args:   []string{"hash", "H H * * *"},
+			status: 2,
+			stderr: "usage: cron hash",
+		},
+		{
+			args:   []string{"hash", "-key", "x", "H,5 * * * *"},
+			status: 1,
+			stderr: "cron hash: ",
+		},
+		{
+			args: []string{"validate", "0 3 * * *", "@hourly"},
+		},
+		{
+			args:   []string{"validate", "0 3 * * *", "61 * * * *"},
+			status: 1,
+			stderr: `"61 * * * *": invalid value 61 for the minute field`,
+		},
+		{
+			args: []string{"validate", "-crontab", good},
+		},
+		{
+			args:   []string{"validate", "-crontab", good, bad},
+			status: 1,
+			stdout: bad + ":2:17: warning: unescaped % in command is a newline, and the text after it is standard input\n" +
+				bad + ":3:1: error: invalid value 61 for the minute field\n",
+		},
+		{
+			args:   []string{"validate", "-crontab", filepath.Join(dir, "missing")},
+			status: 1,
+			stderr: "no such file",
+		},
+		{
+			args:   []string{"validate", "-system", "0 3 * * *"},
+			status: 2,
+			stderr: "usage: cron validate",
+		},
+		{
+			args:   []string{"frobnicate"},
+			status: 2,
+			stderr: `unknown command "frobnicate"`,
+		},
+		{
+			args:   nil,
+			status: 2,
+			stderr: "usage: cron <command>",
+		},
+	} {
+		var stdout, stderr strings.Builder
+		status := run(tt.args, &stdout, &stderr)
+		if status != tt.status || stdout.String() != tt.stdout || !strings.Contains(stderr.String(), tt.stderr) {
+			t.Errorf("cron %q: got status %d, stdout\n%s\nstderr\n%s\nwant status %d, stdout\n%s\nstderr containing %q",
+				tt.args, status, stdout.String(), stderr.String(), tt.status, tt.stdout, tt.stderr)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/cespare/cron"
+)
+
+func runNext(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("next", "[-n count] [-from time] [-tz zone] <expr>", stderr)
+	n := fs.Int("n", 5, "print `count` times")
+	from := fs.String("from", "", "print the times after `time`, in RFC 3339 format (default now)")
+	tz := fs.String("tz", "", "evaluate the schedule in the time `zone`, such as America/New_York (default the local zone)")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	s, err := cron.Parse(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "cron next: %s\n", err)
+		return 1
+	}
+	t := time.Now()
+	if *from != "" {
+		if t, err = time.Parse(time.RFC3339, *from); err != nil {
+			fmt.Fprintf(stderr, "cron next: invalid -from time: %s\n", err)
+			return 2
+		}
+	}
+	// The schedule is evaluated in t's location, so use the chosen zone
+	// rather than the offset given with -from.
+	loc := time.Local
+	var r cron.Recurrence = s
+	if *tz != "" {
+		if loc, err = time.LoadLocation(*tz); err != nil {
+			fmt.Fprintf(stderr, "cron next: %s\n", err)
+			return 2
+		}
+		r = s.In(loc)
+	}
+	t = t.In(loc)
+	for i := 0; i < *n; i++ {
+		// A schedule which fires at all fires indefinitely, so only the
+		// first call of Next can return the zero Time.
+		if t = r.Next(t); t.IsZero() {
+			fmt.Fprintln(stderr, "cron next: schedule never fires")
+			return 1
+		}
+		fmt.Fprintln(stdout, t.Format(time.RFC3339))
+	}
+	return 0
+}
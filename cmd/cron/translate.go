@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cespare/cron"
+)
+
+var dialects = []cron.Dialect{cron.Standard, cron.Jenkins, cron.Quartz, cron.AWS}
+
+// dialectFlag is a flag.Value holding a cron.Dialect, given by its name.
+type dialectFlag struct{ d cron.Dialect }
+
+func (f *dialectFlag) String() string { return f.d.String() }
+
+func (f *dialectFlag) Set(name string) error {
+	var names []string
+	for _, d := range dialects {
+		if strings.EqualFold(name, d.String()) {
+			f.d = d
+			return nil
+		}
+		names = append(names, d.String())
+	}
+	return fmt.Errorf("unknown dialect (want one of %s)", strings.Join(names, ", "))
+}
+
+func runTranslate(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("translate", "[-from dialect] [-to dialect] <expr>", stderr)
+	var from, to dialectFlag
+	fs.Var(&from, "from", "translate from the `dialect`: standard (the default), jenkins, quartz, or aws")
+	fs.Var(&to, "to", "translate to the `dialect` (default standard)")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	expr, err := cron.Translate(fs.Arg(0), from.d, to.d)
+	if err != nil {
+		fmt.Fprintf(stderr, "cron translate: %s\n", err)
+		return 1
+	}
+	fmt.Fprintln(stdout, expr)
+	return 0
+}
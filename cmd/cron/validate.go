@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cespare/cron"
+)
+
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("validate", "[-crontab [-system | -user]] <expr or file>...", stderr)
+	crontab := fs.Bool("crontab", false, "check crontab files, or standard input if a file is -, instead of expressions")
+	system := fs.Bool("system", false, "with -crontab, the files are system crontabs, with a user field")
+	user := fs.Bool("user", false, "with -crontab, the files are user crontabs (default: detect the format)")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() == 0 || !*crontab && (*system || *user) || *system && *user {
+		fs.Usage()
+		return 2
+	}
+	if !*crontab {
+		status := 0
+		for _, expr := range fs.Args() {
+			if _, err := cron.Parse(expr); err != nil {
+				fmt.Fprintf(stderr, "%q: %s\n", expr, err)
+				status = 1
+			}
+		}
+		return status
+	}
+
+	format := cron.DetectCrontab
+	switch {
+	case *system:
+		format = cron.SystemCrontab
+	case *user:
+		format = cron.UserCrontab
+	}
+	status := 0
+	for _, name := range fs.Args() {
+		diags, err := lintFile(name, format)
+		if err != nil {
+			fmt.Fprintf(stderr, "cron validate: %s\n", err)
+			status = 1
+			continue
+		}
+		for _, d := range diags {
+			fmt.Fprintf(stdout, "%s:%s\n", name, d)
+			if d.Severity == cron.SeverityError {
+				status = 1
+			}
+		}
+	}
+	return status
+}
+
+// lintFile checks the named crontab file, or standard input if name is -.
+func lintFile(name string, format cron.CrontabFormat) ([]cron.Diagnostic, error) {
+	if name == "-" {
+		return cron.LintCrontab(os.Stdin, cron.WithCrontabFormat(format))
+	}
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return cron.LintCrontab(f, cron.WithCrontabFormat(format))
+}
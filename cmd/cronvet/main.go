@@ -0,0 +1,230 @@
+// Command cronvet finds invalid constant cron expressions in Go code, so
+// that a typo in a schedule is caught at build time instead of when the
+// program starts.
+//
+// Usage:
+//
+//	cronvet [dir | dir/...]...
+//
+// Cronvet checks the Go packages in the given directories (by default, the
+// current one); a directory ending in /... includes its subdirectories,
+// except for vendor and testdata directories and those starting with . or
+// _. It reports calls of these functions of the github.com/cespare/cron
+// package whose expression is a constant (a string literal or a constant
+// expression, such as a named constant) that the function rejects:
+//
+//	Parse, ParseH, ParseWithDiagnostics, Simplify, ParseUnion,
+//	ParseWeekSchedule, Equivalent
+//
+// Method calls, such as those of a ParseCache, are not checked.
+//
+// Cronvet prints each problem as "file:line:column: message" and exits
+// with status 1 if it finds any.
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/cron"
+)
+
+const cronPath = "github.com/cespare/cron"
+
+// checks gives, for each checked function, a function which returns the
+// error the function returns for an expression.
+var checks = map[string]func(expr string) error{
+	"Parse":                parseErr,
+	"ParseH":               func(expr string) error { _, err := cron.ParseH(expr, 0); return err },
+	"ParseWithDiagnostics": parseErr,
+	"Simplify":             func(expr string) error { _, err := cron.Simplify(expr); return err },
+	"ParseUnion":           func(expr string) error { _, err := cron.ParseUnion(expr); return err },
+	"ParseWeekSchedule":    func(expr string) error { _, err := cron.ParseWeekSchedule(expr); return err },
+	"Equivalent":           parseErr,
+}
+
+func parseErr(expr string) error {
+	_, err := cron.Parse(expr)
+	return err
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		args = []string{"."}
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			fmt.Fprintln(stderr, "usage: cronvet [dir | dir/...]...")
+			return 2
+		}
+	}
+	dirs, err := expandDirs(args)
+	if err != nil {
+		fmt.Fprintf(stderr, "cronvet: %s\n", err)
+		return 1
+	}
+	status := 0
+	for _, dir := range dirs {
+		fset := token.NewFileSet()
+		pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+		if err != nil {
+			fmt.Fprintf(stderr, "cronvet: %s\n", err)
+			status = 1
+			continue
+		}
+		names := make([]string, 0, len(pkgs))
+		for name := range pkgs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			for _, p := range vet(fset, pkgs[name]) {
+				fmt.Fprintf(stdout, "%s: %s\n", fset.Position(p.pos), p.msg)
+				status = 1
+			}
+		}
+	}
+	return status
+}
+
+// expandDirs returns the directories named by args, expanding those which
+// end in /... to include their subdirectories.
+func expandDirs(args []string) ([]string, error) {
+	var dirs []string
+	for _, arg := range args {
+		root := strings.TrimSuffix(arg, "...")
+		if root == arg {
+			dirs = append(dirs, arg)
+			continue
+		}
+		if root = filepath.Clean(root); root == "" {
+			root = "."
+		}
+		err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !fi.IsDir() {
+				return nil
+			}
+			if name := fi.Name(); path != root && (name == "vendor" || name == "testdata" ||
+				strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			if matches, _ := filepath.Glob(filepath.Join(path, "*.go")); len(matches) > 0 {
+				dirs = append(dirs, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dirs, nil
+}
+
+// A problem is an invalid expression found by vet.
+type problem struct {
+	pos token.Pos
+	msg string
+}
+
+// vet returns the problems in pkg, in order.
+func vet(fset *token.FileSet, pkg *ast.Package) []problem {
+	var files []*ast.File
+	for _, f := range pkg.Files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Pos() < files[j].Pos() })
+
+	// Type-check the package to evaluate constant expressions. Imported
+	// packages are not loaded, so there are many errors, but they don't
+	// affect constants defined in the package itself.
+	info := &types.Info{Types: make(map[ast.Expr]types.TypeAndValue)}
+	conf := types.Config{
+		Importer: fakeImporter{},
+		Error:    func(error) {},
+	}
+	conf.Check(pkg.Name, fset, files, info)
+
+	var problems []problem
+	for _, f := range files {
+		cronNames := make(map[string]bool)
+		for _, spec := range f.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil || path != cronPath {
+				continue
+			}
+			name := "cron"
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			cronNames[name] = true
+		}
+		if len(cronNames) == 0 {
+			continue
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok {
+				return true
+			}
+			x, ok := sel.X.(*ast.Ident)
+			if !ok || !cronNames[x.Name] || x.Obj != nil {
+				// x.Obj is set if x is a local variable which shadows
+				// the import.
+				return true
+			}
+			check, ok := checks[sel.Sel.Name]
+			if !ok {
+				return true
+			}
+			nargs := 1
+			if sel.Sel.Name == "Equivalent" {
+				nargs = 2
+			}
+			for i := 0; i < nargs && i < len(call.Args); i++ {
+				tv := info.Types[call.Args[i]]
+				if tv.Value == nil || tv.Value.Kind() != constant.String {
+					continue
+				}
+				expr := constant.StringVal(tv.Value)
+				if err := check(expr); err != nil {
+					problems = append(problems, problem{
+						pos: call.Args[i].Pos(),
+						msg: fmt.Sprintf("invalid cron expression %q in call of %s.%s: %s", expr, x.Name, sel.Sel.Name, err),
+					})
+				}
+			}
+			return true
+		})
+	}
+	return problems
+}
+
+// A fakeImporter imports every package as an empty one.
+type fakeImporter struct{}
+
+func (fakeImporter) Import(path string) (*types.Package, error) {
+	pkg := types.NewPackage(path, filepath.Base(path))
+	pkg.MarkComplete()
+	return pkg, nil
+}
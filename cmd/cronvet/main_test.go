@@ -0,0 +1,96 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cronvet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"jobs/jobs.go": `package jobs
+
+import (
+	"time"
+
+	"github.com/cespare/cron"
+	c2 "github.com/cespare/cron"
+)
+
+const (
+	nightly = "0 3 * * *"
+	typo    = "0 3 * * MOM"
+	prefix  = "*/5 "
+)
+
+var (
+	s1, _ = cron.Parse(nightly)
+	s2, _ = cron.Parse(typo)
+	s3, _ = c2.ParseH("H H * * *", 1)
+	s4, _ = cron.Parse(prefix + "* * * *")
+	s5, _ = cron.Parse(prefix + "* * *")
+	s6, _ = cron.ParseUnion("@daily; 0 12 * * *")
+)
+
+func f(expr string) {
+	cron.Parse(expr) // not constant
+	ok, _ := cron.Equivalent("@daily", "0 0 * * 7")
+	_ = ok
+	var cache cron.ParseCache
+	cache.Parse("bogus") // methods are not checked
+	_ = time.Now()
+}
+
+func g() {
+	cron := struct{ Parse func(string) }{}
+	cron.Parse("bogus") // not the package
+}
+`,
+		"jobs/jobs_test.go": `package jobs_test
+
+import "github.com/cespare/cron"
+
+var _, _ = cron.Simplify("60 * * * *")
+`,
+		"other/other.go": `package other
+
+import "example.com/cron"
+
+var _, _ = cron.Parse("bogus")
+`,
+		"testdata/bad.go": `package bad
+
+import "github.com/cespare/cron"
+
+var _, _ = cron.Parse("bogus")
+`,
+	}
+	for name, text := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(text), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr strings.Builder
+	status := run([]string{dir + "/..."}, &stdout, &stderr)
+	jobs := filepath.Join(dir, "jobs", "jobs.go")
+	jobsTest := filepath.Join(dir, "jobs", "jobs_test.go")
+	want := jobs + `:18:21: invalid cron expression "0 3 * * MOM" in call of cron.Parse: invalid value "MOM" for the day of week field` + "\n" +
+		jobs + `:21:21: invalid cron expression "*/5 * * *" in call of cron.Parse: wrong number of fields in schedule "*/5 * * *" (expected 5)` + "\n" +
+		jobs + `:27:37: invalid cron expression "0 0 * * 7" in call of cron.Equivalent: invalid value 7 for the day of week field` + "\n" +
+		jobsTest + `:5:26: invalid cron expression "60 * * * *" in call of cron.Simplify: invalid value 60 for the minute field` + "\n"
+	if status != 1 || stdout.String() != want || stderr.Len() > 0 {
+		t.Errorf("cronvet: got status %d, stdout\n%s\nstderr\n%s\nwant status 1, stdout\n%s", status, stdout.String(), stderr.String(), want)
+	}
+}
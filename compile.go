@@ -0,0 +1,68 @@
+package cron
+
+import (
+	mathbits "math/bits"
+	"time"
+)
+
+// Compile returns a function equivalent to s.Matches that is specialized for
+// s. Fields with every value are not checked at all, and fields with a single
+// value or a regular step (such as */15) are checked without consulting the
+// bitset. Parts of the time that s doesn't need, such as the date for a
+// schedule that fires every day, are not computed.
+//
+// Compile panics if s is not valid.
+func (s Schedule) Compile() func(time.Time) bool {
+	if !s.Valid() {
+		panic("Compile() called on invalid schedule")
+	}
+	f := s.fieldBits()
+	var m [5]func(int) bool
+	for i := range m {
+		m[i] = compileField(f[i], i)
+	}
+	minute, hour, dom, month, dow := m[0], m[1], m[2], m[3], m[4]
+	if dom == nil && month == nil {
+		if dow == nil {
+			return func(t time.Time) bool {
+				h, min, _ := t.Clock()
+				return (minute == nil || minute(min)) && (hour == nil || hour(h))
+			}
+		}
+		return func(t time.Time) bool {
+			h, min, _ := t.Clock()
+			return (minute == nil || minute(min)) && (hour == nil || hour(h)) && dow(int(t.Weekday()))
+		}
+	}
+	return func(t time.Time) bool {
+		h, min, _ := t.Clock()
+		if (minute != nil && !minute(min)) || (hour != nil && !hour(h)) {
+			return false
+		}
+		_, mo, d := t.Date()
+		return (month == nil || month(int(mo))) &&
+			(dom == nil || dom(d)) &&
+			(dow == nil || dow(int(t.Weekday())))
+	}
+}
+
+// compileField returns a function reporting whether a value is set in bits,
+// the bits of field fieldIndex. It returns nil if every value is set.
+func compileField(bits uint64, fieldIndex int) func(int) bool {
+	min := fieldMin(fieldIndex)
+	switch n := mathbits.OnesCount64(bits); {
+	case n == fieldSizes[fieldIndex]:
+		return nil
+	case n == 1:
+		v := mathbits.TrailingZeros64(bits) + min
+		return func(x int) bool { return x == v }
+	}
+	var vals []int
+	for b := bits; b != 0; b &= b - 1 {
+		vals = append(vals, mathbits.TrailingZeros64(b)+min)
+	}
+	if step, ok := wildcardStep(vals, fieldIndex); ok {
+		return func(x int) bool { return (x-min)%step == 0 }
+	}
+	return func(x int) bool { return bits&(1<<uint(x-min)) != 0 }
+}
@@ -0,0 +1,36 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestCompile(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, expr := range []string{
+		"* * * * *",
+		"*/15 * * * *",
+		"0 * * * *",
+		"0 9 * * *",
+		"0 9 * * MON-FRI",
+		"30 */6 * * SUN",
+		"0 0 1 * *",
+		"0 0 1-7 * MON",
+		"5,17 3-5 10-20/5 JAN,JUL *",
+	} {
+		s := mustParse(t, expr)
+		matches := s.Compile()
+		for i := 0; i < 10000; i++ {
+			tm := time.Unix(r.Int63n(100*365*24*60*60), 0).UTC()
+			if i%2 == 0 {
+				// Times that match are rare for most of the
+				// schedules, so also try occurrences.
+				tm = s.Next(tm)
+			}
+			if got, want := matches(tm), s.Matches(tm); got != want {
+				t.Errorf("Parse(%q).Compile()(%s) = %t; want %t", expr, tm, got, want)
+			}
+		}
+	}
+}
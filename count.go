@@ -0,0 +1,196 @@
+package cron
+
+import (
+	"fmt"
+	"math"
+	mathbits "math/bits"
+	"time"
+)
+
+// PerDay returns the number of times s fires on each day on which it fires
+// at all: the number of its minutes times the number of its hours.
+// PerDay panics if s is not valid.
+func (s Schedule) PerDay() int {
+	if err := s.Validate(); err != nil {
+		panic("PerDay() called on invalid schedule: " + err.Error())
+	}
+	return s.perDay()
+}
+
+func (s Schedule) perDay() int {
+	f := s.fieldBits()
+	return mathbits.OnesCount64(f[0]) * mathbits.OnesCount64(f[1])
+}
+
+// PerMonth returns the number of times s fires in the given month of the
+// given year, taking into account the length of the month (including
+// February of leap years) and the days of the week on which its days fall.
+// The count is computed from s's fields, without finding each time.
+//
+// The count is of wall clock times, so it is exact for a schedule in UTC or
+// in a location without daylight saving time changes in the month. In a
+// location with them, a schedule may fire at a wall clock time which is
+// skipped or repeated.
+//
+// PerMonth panics if s is not valid or month is not in [1, 12].
+func (s Schedule) PerMonth(year int, month time.Month) int {
+	if err := s.Validate(); err != nil {
+		panic("PerMonth() called on invalid schedule: " + err.Error())
+	}
+	if month < time.January || month > time.December {
+		panic(fmt.Sprintf("cron: invalid month %d", month))
+	}
+	return s.daysInMonth(year, month) * s.perDay()
+}
+
+// PerYear returns the number of times s fires in the given year. It is the
+// sum of PerMonth for each month of the year, and the same caveat about
+// daylight saving time applies. PerYear panics if s is not valid.
+func (s Schedule) PerYear(year int) int {
+	if err := s.Validate(); err != nil {
+		panic("PerYear() called on invalid schedule: " + err.Error())
+	}
+	return s.yearDays(year) * s.perDay()
+}
+
+// daysInMonth returns the number of days in the given month on which s
+// fires.
+func (s Schedule) daysInMonth(year int, month time.Month) int {
+	return mathbits.OnesCount64(s.monthDays(year, month))
+}
+
+// monthDays returns the days in the given month on which s fires, with bit
+// 0 for day 1.
+func (s Schedule) monthDays(year int, month time.Month) uint64 {
+	f := s.fieldBits()
+	if f[3]&(1<<uint(month-1)) == 0 {
+		return 0
+	}
+	weekday := (daysSinceEpoch(year, int(month), 1) + 4) % 7 // 1970-01-01 was a Thursday
+	if weekday < 0 {
+		weekday += 7
+	}
+	return monthDayBits(f[2], f[4], year, month, time.Weekday(weekday))
+}
+
+// yearDays returns the number of days in the given year on which s fires.
+func (s Schedule) yearDays(year int) int {
+	days := 0
+	for month := time.January; month <= time.December; month++ {
+		days += s.daysInMonth(year, month)
+	}
+	return days
+}
+
+// gregorianCycleDays is the number of days in 400 years of the Gregorian
+// calendar, after which the calendar (including the days of the week)
+// repeats.
+const gregorianCycleDays = 146097
+
+// AverageInterval returns the average time between consecutive times at
+// which s fires, over the schedule's full period: the 400-year cycle of the
+// Gregorian calendar, after which every schedule repeats. For example, the
+// average interval of "0 0 * * MON-FRI" is 33.6 hours, and that of
+// "0 0 29 2 *" is about 1506 days (a little more than four years, because
+// there is no February 29 in three of every four centurial years).
+//
+// As with PerMonth, the times are wall clock times, as in UTC. A schedule
+// which never fires has an average interval of 0. AverageInterval panics
+// if s is not valid.
+func (s Schedule) AverageInterval() time.Duration {
+	if err := s.Validate(); err != nil {
+		panic("AverageInterval() called on invalid schedule: " + err.Error())
+	}
+	count := s.cycleCount()
+	if count == 0 {
+		return 0
+	}
+	// The cycle is too long to express in nanoseconds, so divide in
+	// steps.
+	minutes := int64(gregorianCycleDays * minutesPerDay)
+	q, r := minutes/count, minutes%count
+	seconds := r * 60
+	return time.Duration(q)*time.Minute + time.Duration(seconds/count)*time.Second +
+		time.Duration(seconds%count*int64(time.Second)/count)
+}
+
+// RelativeRate returns how often a fires relative to b: the number of
+// times a fires over the 400-year cycle of the Gregorian calendar divided
+// by the number of times b fires. For example, the relative rate of
+// "*/10 * * * *" to "0 * * * *" is 6, and that of "0 0 * * MON-FRI" to
+// "0 0 * * *" is 5/7. It is computed from the schedules' fields, as for
+// PerMonth, so it is exact.
+//
+// If b never fires, the relative rate is +Inf, unless a doesn't fire
+// either, in which case it is 1. RelativeRate panics if a or b is not
+// valid.
+func RelativeRate(a, b Schedule) float64 {
+	if err := a.Validate(); err != nil {
+		panic("RelativeRate() called on invalid schedule: " + err.Error())
+	}
+	if err := b.Validate(); err != nil {
+		panic("RelativeRate() called on invalid schedule: " + err.Error())
+	}
+	ca, cb := a.cycleCount(), b.cycleCount()
+	switch {
+	case ca == cb:
+		return 1
+	case cb == 0:
+		return math.Inf(1)
+	}
+	return float64(ca) / float64(cb)
+}
+
+// Busier reports whether a fires more often than b over the long run, as
+// given by RelativeRate. Busier panics if a or b is not valid.
+func Busier(a, b Schedule) bool {
+	return RelativeRate(a, b) > 1
+}
+
+// WeekdayCounts returns the number of times s fires on each day of the
+// week, indexed by time.Weekday (starting with Sunday), over the
+// schedule's full period: the 400-year cycle of the Gregorian calendar.
+// For example, for "0 0 13 * *", the count for Friday is 688, slightly more
+// than for any other day. Review tools can use the counts to find
+// schedules which fire on weekends, or mostly on one day of the week,
+// without listing the times.
+//
+// As with PerMonth, the times are wall clock times, as in UTC.
+// WeekdayCounts panics if s is not valid.
+func (s Schedule) WeekdayCounts() [7]int {
+	if err := s.Validate(); err != nil {
+		panic("WeekdayCounts() called on invalid schedule: " + err.Error())
+	}
+	var counts [7]int
+	perDay := s.perDay()
+	for year := 2000; year < 2400; year++ {
+		for month := time.January; month <= time.December; month++ {
+			bits := s.monthDays(year, month)
+			if bits == 0 {
+				continue
+			}
+			first := (daysSinceEpoch(year, int(month), 1) + 4) % 7 // 1970-01-01 was a Thursday
+			for ; bits != 0; bits &= bits - 1 {
+				day := int64(mathbits.TrailingZeros64(bits))
+				counts[(first+day)%7] += perDay
+			}
+		}
+	}
+	return counts
+}
+
+// cycleDays returns the number of days on which s fires in the 400 years
+// starting with the given year, which is the same for every year.
+func (s Schedule) cycleDays(year int) int {
+	days := 0
+	for y := year; y < year+400; y++ {
+		days += s.yearDays(y)
+	}
+	return days
+}
+
+// cycleCount returns the number of times s fires in the 400-year cycle of
+// the Gregorian calendar.
+func (s Schedule) cycleCount() int64 {
+	return int64(s.cycleDays(2000)) * int64(s.perDay())
+}
@@ -0,0 +1,123 @@
+package cron
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPerPeriod(t *testing.T) {
+	for _, tt := range []struct {
+		expr     string
+		year     int
+		month    time.Month
+		perDay   int
+		perMonth int
+		perYear  int
+	}{
+		{"* * * * *", 2023, time.February, 1440, 28 * 1440, 365 * 1440},
+		{"*/15 9-17 * * *", 2024, time.February, 36, 29 * 36, 366 * 36},
+		{"0 0 29 2 *", 2100, time.February, 1, 0, 0},
+		{"0 0 29 2 *", 2000, time.February, 1, 1, 1},
+		{"0 0 31 * *", 2024, time.April, 1, 0, 7},
+		{"30 8 * * MON-FRI", 2026, time.March, 1, 22, 261},
+		{"0 12 13 * FRI", 2026, time.February, 1, 1, 3},
+		{"0 0 1 1 *", 2026, time.June, 1, 0, 1},
+		{"0 0 30 2 *", 2026, time.February, 1, 0, 0},
+	} {
+		s := mustParse(t, tt.expr)
+		if got := s.PerDay(); got != tt.perDay {
+			t.Errorf("%q.PerDay() = %d; want %d", tt.expr, got, tt.perDay)
+		}
+		if got := s.PerMonth(tt.year, tt.month); got != tt.perMonth {
+			t.Errorf("%q.PerMonth(%d, %s) = %d; want %d", tt.expr, tt.year, tt.month, got, tt.perMonth)
+		}
+		if got := s.PerYear(tt.year); got != tt.perYear {
+			t.Errorf("%q.PerYear(%d) = %d; want %d", tt.expr, tt.year, got, tt.perYear)
+		}
+	}
+}
+
+func TestPerMonthMatchesEachBetween(t *testing.T) {
+	for _, expr := range []string{
+		"0 0 * * 0",
+		"*/20 1,13 1-7 * TUE",
+		"5 4 */3 JAN,FEB,DEC *",
+		"0 0 29-31 * SAT,SUN",
+	} {
+		s := mustParse(t, expr)
+		for _, year := range []int{1900, 2000, 2023, 2024} {
+			for month := time.January; month <= time.December; month++ {
+				from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+				n := 0
+				s.EachBetween(from, from.AddDate(0, 1, 0), func(time.Time) bool {
+					n++
+					return true
+				})
+				if got := s.PerMonth(year, month); got != n {
+					t.Errorf("%q.PerMonth(%d, %s) = %d; EachBetween finds %d", expr, year, month, got, n)
+				}
+			}
+		}
+	}
+}
+
+func TestAverageInterval(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want time.Duration
+	}{
+		{"* * * * *", time.Minute},
+		{"*/15 * * * *", 15 * time.Minute},
+		{"0 0 * * *", 24 * time.Hour},
+		{"@weekly", 7 * 24 * time.Hour},
+		{"0 0 * * MON-FRI", 7 * 24 * time.Hour / 5},
+		{"0 9,17 * * *", 12 * time.Hour},
+		{"0 0 1 1 *", gregorianCycleDays * 86400 * 1000000000 / 400},
+		{"0 0 29 2 *", gregorianCycleDays * 86400 * 1000000000 / 97},
+		{"0 0 30 2 *", 0},
+	} {
+		if got := mustParse(t, tt.expr).AverageInterval(); got != tt.want {
+			t.Errorf("%q.AverageInterval() = %s; want %s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestWeekdayCounts(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want [7]int
+	}{
+		{"0 0 13 * *", [7]int{687, 685, 685, 687, 684, 688, 684}},
+		{"0 9,17 * * MON-FRI", [7]int{0, 41742, 41742, 41742, 41742, 41742, 0}},
+		{"0 0 * * SAT", [7]int{6: 20871}},
+		{"0 0 30 2 *", [7]int{}},
+	} {
+		if got := mustParse(t, tt.expr).WeekdayCounts(); got != tt.want {
+			t.Errorf("%q.WeekdayCounts() = %v; want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestRelativeRate(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want float64
+	}{
+		{"*/10 * * * *", "0 * * * *", 6},
+		{"0 0 * * MON-FRI", "0 0 * * *", 5.0 / 7},
+		{"0 0 * * *", "@daily", 1},
+		{"0 0 1 * *", "0 0 * * SUN", 4800.0 / 20871},
+		{"0 0 * * *", "0 0 30 2 *", math.Inf(1)},
+		{"0 0 30 2 *", "0 0 * * *", 0},
+		{"0 0 30 2 *", "0 0 31 4 *", 1},
+	} {
+		a, b := mustParse(t, tt.a), mustParse(t, tt.b)
+		if got := RelativeRate(a, b); got != tt.want {
+			t.Errorf("RelativeRate(%q, %q) = %g; want %g", tt.a, tt.b, got, tt.want)
+		}
+		if got, want := Busier(a, b), tt.want > 1; got != want {
+			t.Errorf("Busier(%q, %q) = %t; want %t", tt.a, tt.b, got, want)
+		}
+	}
+}
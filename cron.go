@@ -4,6 +4,8 @@ package cron
 import (
 	"errors"
 	"fmt"
+	"hash/fnv"
+	mathbits "math/bits"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -47,6 +49,9 @@ import (
 //   - "@hourly", meaning "0 * * * *".
 //
 // Read http://en.wikipedia.org/wiki/Cron for more information about the format.
+//
+// Parse takes time and memory linear in the length of expr. To bound the
+// size of expressions from untrusted sources, use Limits.
 func Parse(expr string) (Schedule, error) {
 	if strings.HasPrefix(expr, "@") {
 		e, ok := namedSchedules[expr]
@@ -101,12 +106,24 @@ func Parse(expr string) (Schedule, error) {
 //   - "@daily" means "H H * * *"
 //   - "@hourly" means "H * * * *"
 //
+// To spread jobs out by name, as Jenkins does, use a seed given by KeySeed.
+//
 // The idea of the H symbol is borrowed from Jenkins, though the details are a
 // bit different.
 func ParseH(expr string, seed uint64) (Schedule, error) {
 	return parseH(expr, rand.New(rand.NewSource(int64(seed))))
 }
 
+// KeySeed returns the seed for ParseH derived from key, such as the name of
+// a job or a host: the 64-bit FNV-1a hash of key. It is stable across
+// processes and versions of this package, so a job's H schedule does not
+// change when it is restarted or moved.
+func KeySeed(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return h.Sum64()
+}
+
 type rng interface {
 	Intn(n int) int
 }
@@ -141,8 +158,20 @@ func parseH(expr string, r rng) (Schedule, error) {
 }
 
 // Valid reports whether s is a valid schedule (that is, whether it could
-// correspond to some well-formed cron expression).
+// correspond to some well-formed cron expression). Use Validate to learn why
+// a Schedule is not valid.
 func (s Schedule) Valid() bool {
+	return s.Validate() == nil
+}
+
+// Validate returns an error describing why s is not valid, or nil if it is
+// valid. A Schedule is not valid if it is the zero Schedule, which was never
+// parsed or built, or if one of its fields has no values or its encoding is
+// corrupt, as can happen when a Schedule is built from stored data.
+func (s Schedule) Validate() error {
+	if s == (Schedule{}) {
+		return errors.New("zero cron schedule (not created by Parse or a Builder)")
+	}
 outer:
 	for i, size := range fieldSizes {
 		for j := 0; j < size; j++ {
@@ -150,57 +179,86 @@ outer:
 				continue outer
 			}
 		}
-		return false
+		return fmt.Errorf("cron schedule has no values in the %s field", fieldNames[i])
 	}
-	return true
+	if s.b[scheduleBytes-1]>>(scheduleBits%8) != 0 {
+		return errors.New("cron schedule has bits set past its last field")
+	}
+	return nil
+}
+
+// Minutes returns the minutes (0-59) at which s fires, in increasing order.
+// The all result reports whether every minute is included (as with *).
+func (s Schedule) Minutes() (vals []int, all bool) { return s.field(0) }
+
+// Hours returns the hours (0-23) at which s fires, in increasing order.
+// The all result reports whether every hour is included (as with *).
+func (s Schedule) Hours() (vals []int, all bool) { return s.field(1) }
+
+// DaysOfMonth returns the days of the month (1-31) on which s fires, in
+// increasing order. The all result reports whether every day is included
+// (as with *).
+func (s Schedule) DaysOfMonth() (vals []int, all bool) { return s.field(2) }
+
+// Months returns the months (1-12) in which s fires, in increasing order.
+// The all result reports whether every month is included (as with *).
+func (s Schedule) Months() (vals []int, all bool) { return s.field(3) }
+
+// Weekdays returns the days of the week (0-6, starting with Sunday) on which
+// s fires, in increasing order. The all result reports whether every day of
+// the week is included (as with *).
+func (s Schedule) Weekdays() (vals []int, all bool) { return s.field(4) }
+
+func (s Schedule) field(fieldIndex int) (vals []int, all bool) {
+	vals = s.fieldValues(fieldIndex)
+	return vals, len(vals) == fieldSizes[fieldIndex]
 }
 
 // Next gives the smallest time greater than t when the Schedule is satisfied.
+// If s never fires (as with "0 0 30 2 *"), Next returns the zero Time.
 // Next panics if s is not valid.
 func (s Schedule) Next(t time.Time) time.Time {
-	if !s.Valid() {
-		panic("Next() called on invalid schedule")
+	if err := s.Validate(); err != nil {
+		panic("Next() called on invalid schedule: " + err.Error())
 	}
-	// Start t off at the earliest possible subsequent minute.
-	t = t.Truncate(time.Minute).Add(time.Minute)
-
-	for {
-		if !s.matchesMonth(t) {
-			t = advanceMonth(t)
-			continue
-		}
-		if !s.matchesDay(t) {
-			t = advanceDay(t)
-			continue
-		}
-		if !s.matchesHour(t) {
-			t = advanceHour(t)
-			continue
-		}
-		if !s.matchesMinute(t) {
-			t = advanceMinute(t)
-			continue
-		}
-		return t
+	if s.normalize() == (Schedule{}) {
+		return time.Time{}
 	}
+	if t.Location() == time.UTC {
+		return s.nextUTC(t)
+	}
+	return s.next(t)
 }
 
-func advanceMonth(t time.Time) time.Time {
-	year, month, _ := t.Date()
-	return time.Date(year, month+1, 1, 0, 0, 0, 0, t.Location())
-}
-
-func advanceDay(t time.Time) time.Time {
-	year, month, day := t.Date()
-	return time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+func (s Schedule) next(t time.Time) time.Time {
+	// Search for the next matching wall clock time using integer
+	// arithmetic on its fields (see nextUTC), and only convert wall clock
+	// times to instants in t's location to check them.
+	return s.In(t.Location()).Next(t)
 }
 
-func advanceHour(t time.Time) time.Time {
-	return t.Truncate(time.Hour).Add(time.Hour)
+// EachBetween calls fn with each time at which s fires that is no earlier
+// than from and before to, in order, until fn returns false. The times are in
+// from's location. EachBetween panics if s is not valid.
+func (s Schedule) EachBetween(from, to time.Time, fn func(time.Time) bool) {
+	if !s.Valid() {
+		panic("EachBetween() called on invalid schedule")
+	}
+	for t := s.Next(from.Add(-time.Nanosecond)); !t.IsZero() && t.Before(to); t = s.Next(t) {
+		if !fn(t) {
+			return
+		}
+	}
 }
 
-func advanceMinute(t time.Time) time.Time {
-	return t.Truncate(time.Minute).Add(time.Minute)
+// Matches reports whether s fires at the minute containing t.
+//
+// Matches considers only the wall clock time of t in t's location, so both
+// instants of a wall clock time that is repeated when the clocks go back (as
+// at the end of daylight saving time) match equally. Use ZonedSchedule.Matches
+// to distinguish them.
+func (s Schedule) Matches(t time.Time) bool {
+	return s.matchesMonth(t) && s.matchesDay(t) && s.matchesHour(t) && s.matchesMinute(t)
 }
 
 func (s Schedule) matchesMonth(t time.Time) bool {
@@ -279,6 +337,35 @@ var namedHSchedules = map[string]string{
 	"@hourly":  "H * * * *",
 }
 
+// Days of the week and months, for use with Builder. They are the same as the
+// corresponding constants of the time package, and their integer values are
+// the values used in cron expressions and returned by the Schedule field
+// accessors (such as Weekdays).
+const (
+	Sunday    = time.Sunday
+	Monday    = time.Monday
+	Tuesday   = time.Tuesday
+	Wednesday = time.Wednesday
+	Thursday  = time.Thursday
+	Friday    = time.Friday
+	Saturday  = time.Saturday
+)
+
+const (
+	January   = time.January
+	February  = time.February
+	March     = time.March
+	April     = time.April
+	May       = time.May
+	June      = time.June
+	July      = time.July
+	August    = time.August
+	September = time.September
+	October   = time.October
+	November  = time.November
+	December  = time.December
+)
+
 var monthNames = []string{
 	"january",
 	"february",
@@ -465,10 +552,73 @@ func (s Schedule) set(off int) Schedule {
 	return s
 }
 
+func (s Schedule) unset(off int) Schedule {
+	s.b[off/8] &^= 1 << uint(off%8)
+	return s
+}
+
 func (s Schedule) isSet(off int) bool {
 	return s.b[off/8]&(1<<uint(off%8)) > 0
 }
 
+// fieldBits returns the bits of each field of s, with the bit for the
+// field's smallest value in the least significant position.
+func (s Schedule) fieldBits() [5]uint64 {
+	var w [3]uint64
+	for i, b := range s.b {
+		w[i/8] |= uint64(b) << uint(8*(i%8))
+	}
+	var f [5]uint64
+	for i, off := range fieldOffsets {
+		v := w[off/64] >> uint(off%64)
+		if off%64 != 0 {
+			v |= w[off/64+1] << uint(64-off%64)
+		}
+		f[i] = v & (1<<uint(fieldSizes[i]) - 1)
+	}
+	return f
+}
+
+// nextBit returns the position of the least significant set bit in bits at
+// or above position from. It reports false if there is no such bit.
+func nextBit(bits uint64, from int) (int, bool) {
+	bits = bits >> uint(from) << uint(from)
+	if bits == 0 {
+		return 0, false
+	}
+	return mathbits.TrailingZeros64(bits), true
+}
+
+// nextDay returns the first day of the month on or after day which is set in
+// both the day of month bits and the day of week bits. The weekday is the
+// day of the week of day. It reports false if there is no such day in the
+// month.
+func nextDay(domBits, dowBits uint64, year int, month time.Month, day int, weekday time.Weekday) (int, bool) {
+	first := time.Weekday((int(weekday) - (day-1)%7 + 7) % 7)
+	d, ok := nextBit(monthDayBits(domBits, dowBits, year, month, first), day-1)
+	return d + 1, ok
+}
+
+// monthDayBits returns the days of the month (with bit 0 for day 1) which
+// are set in both the day of month bits and the day of week bits. The
+// weekday is the day of the week of day 1.
+func monthDayBits(domBits, dowBits uint64, year int, month time.Month, weekday time.Weekday) uint64 {
+	// Line up the day of week bits with the days of the month, starting
+	// with the weekday of day 1, and repeat them for five weeks.
+	first := int(weekday)
+	week := (dowBits>>uint(first) | dowBits<<uint(7-first)) & 0x7f
+	days := week | week<<7 | week<<14 | week<<21 | week<<28
+	return days & domBits & (1<<uint(daysIn(year, month)) - 1)
+}
+
+// daysIn returns the number of days in the given month.
+func daysIn(year int, month time.Month) int {
+	if month == time.February && (year%4 != 0 || (year%100 == 0 && year%400 != 0)) {
+		return 28
+	}
+	return maxMonthDays[month-1]
+}
+
 func (s Schedule) union(s1 Schedule) Schedule {
 	for i := range s.b {
 		s.b[i] |= s1.b[i]
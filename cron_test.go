@@ -1,6 +1,7 @@
 package cron
 
 import (
+	"math/rand"
 	"strings"
 	"testing"
 	"time"
@@ -36,6 +37,72 @@ func toTestSchedule(s Schedule) testSchedule {
 	return ts
 }
 
+func TestFieldAccessors(t *testing.T) {
+	type field struct {
+		vals []int
+		all  bool
+	}
+	for _, tt := range []struct {
+		expr string
+		want [5]field
+	}{
+		{"* * * * *", [5]field{
+			{seq(0, 59), true},
+			{seq(0, 23), true},
+			{seq(1, 31), true},
+			{seq(1, 12), true},
+			{seq(0, 6), true},
+		}},
+		{"1,3-5 22-1 31 FEB,JAN SAT", [5]field{
+			{[]int{1, 3, 4, 5}, false},
+			{[]int{0, 1, 22, 23}, false},
+			{[]int{31}, false},
+			{[]int{1, 2}, false},
+			{[]int{6}, false},
+		}},
+		{"0-59 */2 1-31 * 0-6", [5]field{
+			{seq(0, 59), true},
+			{[]int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22}, false},
+			{seq(1, 31), true},
+			{seq(1, 12), true},
+			{seq(0, 6), true},
+		}},
+	} {
+		s := mustParse(t, tt.expr)
+		var got [5]field
+		for i, f := range []func() ([]int, bool){
+			s.Minutes,
+			s.Hours,
+			s.DaysOfMonth,
+			s.Months,
+			s.Weekdays,
+		} {
+			got[i].vals, got[i].all = f()
+		}
+		if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(field{})); diff != "" {
+			t.Errorf("fields of %q: (-want, +got)\n%s", tt.expr, diff)
+		}
+	}
+}
+
+// seq returns the integers from lo to hi, inclusive.
+func seq(lo, hi int) []int {
+	var vals []int
+	for v := lo; v <= hi; v++ {
+		vals = append(vals, v)
+	}
+	return vals
+}
+
+func mustParse(t *testing.T, expr string) Schedule {
+	t.Helper()
+	s, err := Parse(expr)
+	if err != nil {
+		t.Fatalf("Parse(%q): %s", expr, err)
+	}
+	return s
+}
+
 func TestParse(t *testing.T) {
 	for _, tt := range []struct {
 		expr string
@@ -162,6 +229,22 @@ func TestParseH(t *testing.T) {
 	}
 }
 
+func TestKeySeed(t *testing.T) {
+	// The seeds must not change, since they determine the times of
+	// stored H schedules.
+	for _, tt := range []struct {
+		key  string
+		want uint64
+	}{
+		{"", 0xcbf29ce484222325},
+		{"a", 0xaf63dc4c8601ec8c},
+	} {
+		if got := KeySeed(tt.key); got != tt.want {
+			t.Errorf("KeySeed(%q) = %#x; want %#x", tt.key, got, tt.want)
+		}
+	}
+}
+
 func TestValid(t *testing.T) {
 	s, err := Parse("* * * * *")
 	if err != nil {
@@ -175,6 +258,34 @@ func TestValid(t *testing.T) {
 	}
 }
 
+func TestValidate(t *testing.T) {
+	if err := mustParse(t, "0 0 30 2 *").Validate(); err != nil {
+		t.Errorf("Validate on a schedule which never fires: %s", err)
+	}
+	noHours := mustParse(t, "* * * * *")
+	for i := 0; i < hours; i++ {
+		noHours = noHours.unset(hourOffset + i)
+	}
+	highBits := mustParse(t, "* * * * *")
+	highBits.b[scheduleBytes-1] |= 0x80
+	for _, tt := range []struct {
+		s    Schedule
+		want string
+	}{
+		{Schedule{}, "zero cron schedule (not created by Parse or a Builder)"},
+		{noHours, "cron schedule has no values in the hour field"},
+		{highBits, "cron schedule has bits set past its last field"},
+	} {
+		err := tt.s.Validate()
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("Validate(%v): got error %v; want %q", tt.s.b, err, tt.want)
+		}
+		if tt.s.Valid() {
+			t.Errorf("Valid(%v) = true", tt.s.b)
+		}
+	}
+}
+
 func TestNext(t *testing.T) {
 	const layout = "2006-01-02 15:04"
 	parseTime := func(s string) time.Time {
@@ -208,3 +319,131 @@ func TestNext(t *testing.T) {
 		}
 	}
 }
+
+func TestNextMatchesBruteForce(t *testing.T) {
+	exprs := []string{
+		"*/7 3-5 * * *",
+		"0 11 * * *",
+		"30 2 * * *",
+		"15,45 1 * * *",
+		"0 0 1-7 * MON",
+		"59 23 * * FRI",
+		"*/20 0-3 * * SUN",
+	}
+	var locs []*time.Location
+	for _, name := range []string{"UTC", "America/New_York", "Asia/Kolkata", "Australia/Lord_Howe"} {
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		locs = append(locs, loc)
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, expr := range exprs {
+		s := mustParse(t, expr)
+		for _, loc := range locs {
+			for i := 0; i < 20; i++ {
+				// Times during 2026, to the second.
+				t1 := time.Unix(1767225600+r.Int63n(365*24*60*60), 0).In(loc)
+				want := t1.Truncate(time.Minute).Add(time.Minute)
+				for !s.Matches(want) {
+					want = want.Add(time.Minute)
+				}
+				if got := s.Next(t1); !got.Equal(want) {
+					t.Errorf("Parse(%q).Next(%s) = %s; want %s", expr, t1, got, want)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkNext(b *testing.B) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for _, bb := range []struct {
+		name string
+		expr string
+	}{
+		{"every-minute", "* * * * *"},
+		{"daily", "0 3 * * *"},
+		{"weekdays", "*/15 9-17 * * MON-FRI"},
+		{"first-monday", "0 0 1-7 * MON"},
+		{"last-minute-of-year", "59 23 31 12 *"},
+		{"leap-day", "0 0 29 2 *"},
+		{"friday-13th", "0 0 13 * FRI"},
+	} {
+		s, err := Parse(bb.expr)
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, loc := range []*time.Location{time.UTC, ny} {
+			b.Run(bb.name+"/"+loc.String(), func(b *testing.B) {
+				start := time.Date(2026, 3, 1, 0, 0, 0, 0, loc)
+				end := time.Date(2400, 1, 1, 0, 0, 0, 0, loc)
+				t := start
+				for i := 0; i < b.N; i++ {
+					if t = s.Next(t); t.After(end) {
+						t = start
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestEachBetween(t *testing.T) {
+	s := mustParse(t, "*/20 * * * *")
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	var got []string
+	s.EachBetween(from, to, func(t time.Time) bool {
+		got = append(got, t.Format("15:04"))
+		return true
+	})
+	if diff := cmp.Diff(got, []string{"00:00", "00:20", "00:40"}); diff != "" {
+		t.Errorf("EachBetween (-got, +want):\n%s", diff)
+	}
+
+	got = nil
+	s.EachBetween(from, to, func(t time.Time) bool {
+		got = append(got, t.Format("15:04"))
+		return len(got) < 2
+	})
+	if diff := cmp.Diff(got, []string{"00:00", "00:20"}); diff != "" {
+		t.Errorf("EachBetween stopped early (-got, +want):\n%s", diff)
+	}
+
+	var n int
+	s.EachBetween(to, from, func(time.Time) bool { n++; return true })
+	if n != 0 {
+		t.Errorf("EachBetween with to before from called fn %d times", n)
+	}
+	mustParse(t, "0 0 30 2 *").EachBetween(from, to, func(time.Time) bool { n++; return true })
+	if n != 0 {
+		t.Errorf("EachBetween with a schedule that never fires called fn %d times", n)
+	}
+}
+
+func TestNextNeverFires(t *testing.T) {
+	s := mustParse(t, "0 0 30 2 *")
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		name string
+		got  time.Time
+	}{
+		{"Next", s.Next(from)},
+		{"Next in New York", s.Next(from.In(ny))},
+		{"NextUTC", s.NextUTC(from)},
+		{"ZonedSchedule.Next", s.In(ny).Next(from)},
+	} {
+		if !tt.got.IsZero() {
+			t.Errorf("%s = %s; want the zero Time", tt.name, tt.got)
+		}
+	}
+}
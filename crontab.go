@@ -0,0 +1,1051 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// An Entry is a job listed in a crontab file.
+type Entry struct {
+	Line     int      // the line number, starting at 1
+	Schedule Schedule // not valid if Reboot is true
+	Reboot   bool     // whether the entry runs at startup (@reboot)
+	User     string   // the user to run the command as, in a system crontab
+
+	// Command is the command which the shell runs: the text of the entry
+	// up to its first % that is not escaped by a backslash, with the
+	// escaping backslashes removed, as cron does. So the command
+	// "date +\%Y" runs date +%Y.
+	Command string
+	// Stdin is the data written to the command's standard input: the text
+	// after the first unescaped %, in which cron turns each unescaped % into
+	// a newline and \% into %. It is empty if there is no unescaped %.
+	Stdin string
+
+	// Env holds the environment assignments in effect for the entry, that
+	// is, those above it in the file. Later assignments to a variable
+	// override earlier ones.
+	Env map[string]string
+	// Location is the time zone in which the schedule is evaluated, as
+	// given by the CRON_TZ variable in Env or, if it is unset or empty, TZ.
+	// It is nil if neither is set, meaning the system's time zone. FormatCrontab
+	// writes Env and ignores Location.
+	Location *time.Location
+	// Comments holds the text of the comment lines directly above the
+	// entry, without the # and the following space, if any.
+	Comments []string
+	// Metadata holds the annotations in the comments directly above the
+	// entry which begin with "cron:", such as
+	//
+	//	# cron:name=nightly-backup owner=dba
+	//
+	// These comments are not included in Comments. An annotation is a
+	// list of key=value pairs separated by spaces, in which a value may be
+	// a double-quoted Go string, such as desc="Back up the database". If
+	// a key appears more than once, the last value is used.
+	Metadata map[string]string
+}
+
+// Recurrence returns e's Schedule, or Reboot{} if e is an @reboot entry. If
+// e has a Location, the Schedule is evaluated there, handling daylight
+// saving time changes as Vixie cron does: times skipped when the clocks go
+// forward are run at the first instant afterwards, and times repeated when
+// the clocks go back are run twice only if the schedule's minute or hour
+// field matches every value, as * does.
+func (e Entry) Recurrence() Recurrence {
+	switch {
+	case e.Reboot:
+		return Reboot{}
+	case e.Location == nil:
+		return e.Schedule
+	}
+	z := e.Schedule.In(e.Location).WithGapPolicy(GapFireAfter)
+	_, allMinutes := e.Schedule.Minutes()
+	_, allHours := e.Schedule.Hours()
+	if !allMinutes && !allHours {
+		z = z.WithFoldPolicy(FoldFirst)
+	}
+	return z
+}
+
+// Shell returns the shell with which e's command is run: the value of
+// SHELL in e's Env or, if it is not set, /bin/sh.
+func (e Entry) Shell() string {
+	if sh, ok := e.Env["SHELL"]; ok {
+		return sh
+	}
+	return "/bin/sh"
+}
+
+// MailTo returns the value of MAILTO in e's Env, which is where cron mails
+// the output of e's command, and reports whether it is set. If it is not
+// set, cron mails the owner of the crontab; if it is empty, cron does not
+// send mail.
+func (e Entry) MailTo() (string, bool) {
+	to, ok := e.Env["MAILTO"]
+	return to, ok
+}
+
+// A CrontabError describes a problem with a line of a crontab file.
+type CrontabError struct {
+	Line int // the line number, starting at 1
+	Err  error
+}
+
+func (e *CrontabError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e *CrontabError) Unwrap() error { return e.Err }
+
+// A CrontabFormat is a variant of the crontab file format.
+type CrontabFormat int
+
+const (
+	// UserCrontab is the format of a user's crontab, as installed by
+	// crontab(1) in /var/spool/cron.
+	UserCrontab CrontabFormat = iota
+	// SystemCrontab is the format of /etc/crontab and the files in
+	// /etc/cron.d, in which each entry has a user field between the
+	// schedule and the command.
+	SystemCrontab
+	// DetectCrontab means that the format is chosen based on the file's
+	// contents. The file is taken to be a SystemCrontab if one of its
+	// comments is a header naming a user column, such as
+	//
+	//	# m h dom mon dow user  command
+	//
+	// or if the command of every entry begins with "root" and a space.
+	// Otherwise, it is taken to be a UserCrontab.
+	DetectCrontab
+)
+
+// A CrontabOption configures ParseCrontab.
+type CrontabOption func(*crontabConfig)
+
+type crontabConfig struct {
+	format CrontabFormat
+}
+
+// WithCrontabFormat makes ParseCrontab parse the given format of crontab
+// file. The default is UserCrontab.
+func WithCrontabFormat(f CrontabFormat) CrontabOption {
+	return func(c *crontabConfig) { c.format = f }
+}
+
+// crontabSchedules are the named schedules which crontab files accept in
+// addition to those recognized by Parse.
+var crontabSchedules = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@midnight": "0 0 * * *",
+}
+
+// ParseCrontab parses a crontab file, such as a user's file in
+// /var/spool/cron, and returns its entries in order.
+//
+// Each line of the file is blank, a comment, an environment assignment, or
+// an entry. Comments are lines whose first non-blank character is #.
+// Environment assignments have the form
+//
+//	name = value
+//
+// in which the spaces are optional and the value may be quoted. They apply
+// to the entries after them (see Entry.Env). An assignment to CRON_TZ or
+// TZ sets the time zone of the following entries' schedules (see
+// Entry.Location); if the time zone is unknown, ParseCrontab returns an
+// error.
+//
+// An entry is a schedule followed by a command, which is the rest of the
+// line. The schedule is five fields in the format accepted by Parse or one
+// of the named schedules. Besides those recognized by Parse, crontab files
+// accept "@yearly" and "@annually", meaning "0 0 1 1 *", "@midnight",
+// meaning "0 0 * * *", and "@reboot" (see Entry.Reboot).
+//
+// In a system crontab (see WithCrontabFormat), the schedule is followed by
+// the name of a user and then the command.
+//
+// If a line is invalid, ParseCrontab returns a *CrontabError.
+func ParseCrontab(r io.Reader, opts ...CrontabOption) ([]Entry, error) {
+	c, err := ReadCrontab(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.Entries(), nil
+}
+
+// A Crontab is a crontab file which can be edited while keeping the rest of
+// its text, including its comments and formatting, as it is. Use
+// ReadCrontab to create a Crontab.
+type Crontab struct {
+	system  bool
+	lines   []string // without their newlines
+	newline bool     // whether the last line ends with a newline
+	entries []Entry
+	env     map[string]string // in effect at the end of the file
+}
+
+// ReadCrontab reads a crontab file, as described for ParseCrontab.
+func ReadCrontab(r io.Reader, opts ...CrontabOption) (*Crontab, error) {
+	var cfg crontabConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &Crontab{env: make(map[string]string), newline: true}
+	if len(data) > 0 {
+		c.lines = strings.Split(string(data), "\n")
+		if c.lines[len(c.lines)-1] == "" {
+			c.lines = c.lines[:len(c.lines)-1]
+		} else {
+			c.newline = false
+		}
+	}
+	c.system = cfg.format == SystemCrontab || cfg.format == DetectCrontab && isSystemCrontab(c.lines)
+	var (
+		loc      *time.Location
+		comments []string
+		metadata map[string]string
+	)
+	for i, line := range c.lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			comments, metadata = nil, nil
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			text := strings.TrimPrefix(line[1:], " ")
+			if !isMetadata(text) {
+				comments = append(comments, text)
+				continue
+			}
+			if metadata == nil {
+				metadata = make(map[string]string)
+			}
+			if err := parseMetadata(text, metadata); err != nil {
+				return nil, &CrontabError{Line: i + 1, Err: err}
+			}
+			continue
+		}
+		if name, value, ok := parseEnvAssignment(line); ok {
+			c.env[name] = value
+			comments, metadata = nil, nil
+			if name == "CRON_TZ" || name == "TZ" {
+				var err error
+				if loc, err = crontabLocation(c.env); err != nil {
+					return nil, &CrontabError{Line: i + 1, Err: err}
+				}
+			}
+			continue
+		}
+		e, _, err := parseCrontabEntry(line, c.system)
+		if err != nil {
+			return nil, &CrontabError{Line: i + 1, Err: err}
+		}
+		e.Line = i + 1
+		e.Location = loc
+		e.Comments, e.Metadata = comments, metadata
+		comments, metadata = nil, nil
+		if len(c.env) > 0 {
+			e.Env = copyEnv(c.env)
+		}
+		c.entries = append(c.entries, e)
+	}
+	return c, nil
+}
+
+// copyEnv returns a copy of env, or of another map of strings.
+func copyEnv(env map[string]string) map[string]string {
+	env1 := make(map[string]string, len(env))
+	for name, value := range env {
+		env1[name] = value
+	}
+	return env1
+}
+
+// sameEnv reports whether env1 and env2, or other maps of strings, have
+// the same contents.
+func sameEnv(env1, env2 map[string]string) bool {
+	if len(env1) != len(env2) {
+		return false
+	}
+	for name, value := range env1 {
+		if v, ok := env2[name]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// Entries returns c's entries, in order.
+func (c *Crontab) Entries() []Entry {
+	entries := make([]Entry, len(c.entries))
+	for i, e := range c.entries {
+		if e.Env != nil {
+			e.Env = copyEnv(e.Env)
+		}
+		e.Comments = append([]string(nil), e.Comments...)
+		if e.Metadata != nil {
+			e.Metadata = copyEnv(e.Metadata)
+		}
+		entries[i] = e
+	}
+	return entries
+}
+
+// Set replaces the schedule, user, command, and metadata of the entry at
+// index i in c's Entries with those of e. It changes only the text that it
+// must: if e's schedule fires at the same times, the entry's schedule is
+// left as written; otherwise, only the fields that differ are rewritten,
+// and the space after them is adjusted to keep the columns that follow
+// aligned, if possible. If the metadata changes, the entry's annotations
+// are replaced by one just above the entry. Set ignores e's other fields,
+// since they are determined by the lines around the entry. Set returns an
+// error if e cannot be written, as for FormatCrontab.
+func (c *Crontab) Set(i int, e Entry) error {
+	old := c.entries[i]
+	if err := c.check(e); err != nil {
+		return err
+	}
+	l := parseEntryLayout(c.lines[old.Line-1], c.system)
+	if e.Reboot != old.Reboot || !e.Reboot && e.Schedule != old.Schedule {
+		l.setSchedule(e)
+	}
+	if e.User != old.User {
+		l.userSep = realign(l.userSep, len(l.user)-len(e.User))
+		l.user = e.User
+	}
+	if e.Command != old.Command || e.Stdin != old.Stdin {
+		l.command = joinCommand(e.Command, e.Stdin)
+	}
+	c.lines[old.Line-1] = l.String()
+	old.Schedule, old.Reboot, old.User = e.Schedule, e.Reboot, e.User
+	old.Command, old.Stdin = e.Command, e.Stdin
+	c.entries[i] = old
+	if !sameEnv(e.Metadata, old.Metadata) {
+		c.setMetadata(i, e.Metadata)
+	}
+	return nil
+}
+
+// setMetadata replaces the annotations of the entry at index i with one
+// for md.
+func (c *Crontab) setMetadata(i int, md map[string]string) {
+	e := &c.entries[i]
+	var lines []string
+	for _, line := range c.lines[c.commentStart(i) : e.Line-1] {
+		if !isMetadata(commentText(line)) {
+			lines = append(lines, line)
+		}
+	}
+	if len(md) > 0 {
+		lines = append(lines, formatMetadata(md))
+		e.Metadata = copyEnv(md)
+	} else {
+		e.Metadata = nil
+	}
+	c.replaceLines(c.commentStart(i), e.Line-1, lines)
+}
+
+// commentStart returns the index in c.lines of the first of the comments
+// directly above the entry at index i.
+func (c *Crontab) commentStart(i int) int {
+	start := c.entries[i].Line - 1
+	for start > 0 && strings.HasPrefix(strings.TrimSpace(c.lines[start-1]), "#") {
+		start--
+	}
+	return start
+}
+
+// replaceLines replaces c.lines[start:end] with lines, updating the line
+// numbers of the entries after them.
+func (c *Crontab) replaceLines(start, end int, lines []string) {
+	delta := len(lines) - (end - start)
+	c.lines = append(c.lines[:start], append(lines, c.lines[end:]...)...)
+	for j := range c.entries {
+		if c.entries[j].Line > start {
+			c.entries[j].Line += delta
+		}
+	}
+}
+
+// commentText returns the text of line, a comment, as stored in
+// Entry.Comments.
+func commentText(line string) string {
+	return strings.TrimPrefix(strings.TrimSpace(line)[1:], " ")
+}
+
+// commentLine returns the comment line with the given text.
+func commentLine(text string) string {
+	if text == "" {
+		return "#"
+	}
+	return "# " + text
+}
+
+// Add adds e to the end of c, preceded by its Comments and an annotation
+// holding its Metadata. If e's Env is not
+// nil, Add also adds the assignments needed to change the environment in
+// effect at the end of c to e's Env. Add returns an error if e cannot be
+// written, as for FormatCrontab, or if its environment sets an unknown time
+// zone.
+func (c *Crontab) Add(e Entry) error {
+	if err := c.check(e); err != nil {
+		return err
+	}
+	var assignments []string
+	env := c.env
+	if e.Env != nil {
+		assignments = envChanges(c.env, e.Env)
+		env = copyEnv(e.Env)
+	}
+	loc, err := crontabLocation(env)
+	if err != nil {
+		return err
+	}
+	comments := entryComments(e)
+	if n := len(c.lines); n > 0 && strings.TrimSpace(c.lines[n-1]) != "" && (len(assignments) > 0 || len(comments) > 0) {
+		c.lines = append(c.lines, "")
+	}
+	c.lines = append(c.lines, assignments...)
+	c.lines = append(c.lines, comments...)
+	var l entryLayout
+	l.setSchedule(e)
+	if c.system {
+		l.user, l.userSep = e.User, " "
+	}
+	l.command = joinCommand(e.Command, e.Stdin)
+	c.lines = append(c.lines, l.String())
+	c.env = env
+	e.Line = len(c.lines)
+	e.Location = loc
+	if len(env) > 0 {
+		e.Env = copyEnv(env)
+	} else {
+		e.Env = nil
+	}
+	e.Comments = append([]string(nil), e.Comments...)
+	if len(e.Metadata) > 0 {
+		e.Metadata = copyEnv(e.Metadata)
+	} else {
+		e.Metadata = nil
+	}
+	c.entries = append(c.entries, e)
+	c.newline = true
+	return nil
+}
+
+// Remove removes the entry at index i in c's Entries, along with the
+// comments directly above it.
+func (c *Crontab) Remove(i int) {
+	start, end := c.commentStart(i), c.entries[i].Line
+	c.entries = append(c.entries[:i], c.entries[i+1:]...)
+	c.replaceLines(start, end, nil)
+}
+
+// check reports why e cannot be written to c, if it cannot.
+func (c *Crontab) check(e Entry) error {
+	if err := checkCrontabEntry(e); err != nil {
+		return err
+	}
+	if c.system && e.User == "" {
+		return errors.New("missing user in system crontab")
+	}
+	if !c.system && e.User != "" {
+		return errors.New("user in non-system crontab")
+	}
+	return nil
+}
+
+// WriteTo writes c's text to w.
+func (c *Crontab) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	for i, line := range c.lines {
+		b.WriteString(line)
+		if i < len(c.lines)-1 || c.newline {
+			b.WriteByte('\n')
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// An entryLayout is the text of an entry line split into its parts.
+type entryLayout struct {
+	indent  string
+	sched   []string // five fields or a named schedule
+	sep     []string // the space after each of sched
+	user    string   // in a system crontab
+	userSep string
+	command string
+	rest    string // the space after the command
+}
+
+// parseEntryLayout splits line, a valid entry, into its parts.
+func parseEntryLayout(line string, system bool) entryLayout {
+	var l entryLayout
+	trimmed := strings.TrimLeft(line, " \t")
+	l.indent = line[:len(line)-len(trimmed)]
+	n := 5
+	if strings.HasPrefix(trimmed, "@") {
+		n = 1
+	}
+	next := func() (field, sep string) {
+		i := strings.IndexAny(trimmed, " \t")
+		field, trimmed = trimmed[:i], trimmed[i:]
+		rest := strings.TrimLeft(trimmed, " \t")
+		sep, trimmed = trimmed[:len(trimmed)-len(rest)], rest
+		return field, sep
+	}
+	for i := 0; i < n; i++ {
+		field, sep := next()
+		l.sched = append(l.sched, field)
+		l.sep = append(l.sep, sep)
+	}
+	if system {
+		l.user, l.userSep = next()
+	}
+	l.command = strings.TrimRight(trimmed, " \t")
+	l.rest = trimmed[len(l.command):]
+	return l
+}
+
+// setSchedule replaces the fields of l's schedule which differ from e's.
+func (l *entryLayout) setSchedule(e Entry) {
+	var fields []string
+	switch {
+	case e.Reboot:
+		fields = []string{"@reboot"}
+	default:
+		fields = strings.Fields(e.Schedule.String())
+	}
+	if len(fields) != len(l.sched) {
+		last := " "
+		if len(l.sep) > 0 {
+			last = l.sep[len(l.sep)-1]
+		}
+		l.sched = fields
+		l.sep = make([]string, len(fields))
+		for i := range l.sep {
+			l.sep[i] = " "
+		}
+		l.sep[len(l.sep)-1] = last
+		return
+	}
+	if e.Reboot {
+		l.sched[0] = fields[0]
+		return
+	}
+	for i, f := range fields {
+		if fieldBits(l.sched[i], i) == e.Schedule.fieldBits()[i] {
+			continue
+		}
+		l.sep[i] = realign(l.sep[i], len(l.sched[i])-len(f))
+		l.sched[i] = f
+	}
+}
+
+// fieldBits returns the values of a field of a Schedule written as text.
+func fieldBits(text string, fieldIndex int) uint64 {
+	expr := []string{"*", "*", "*", "*", "*"}
+	expr[fieldIndex] = text
+	s, err := Parse(strings.Join(expr, " "))
+	if err != nil {
+		return 0
+	}
+	return s.fieldBits()[fieldIndex]
+}
+
+// realign adjusts sep, the space after a field whose text grows shorter by
+// delta bytes, to keep the text after it in place, if sep is made of spaces
+// and is long enough.
+func realign(sep string, delta int) string {
+	if strings.Trim(sep, " ") != "" {
+		return sep
+	}
+	n := len(sep) + delta
+	if n < 1 {
+		n = 1
+	}
+	return strings.Repeat(" ", n)
+}
+
+func (l entryLayout) String() string {
+	var b strings.Builder
+	b.WriteString(l.indent)
+	for i, f := range l.sched {
+		b.WriteString(f + l.sep[i])
+	}
+	if l.user != "" {
+		b.WriteString(l.user + l.userSep)
+	}
+	b.WriteString(l.command + l.rest)
+	return b.String()
+}
+
+// crontabLocation returns the time zone given by CRON_TZ in env or, if it
+// is unset or empty, TZ, or nil if neither is set.
+func crontabLocation(env map[string]string) (*time.Location, error) {
+	name := env["CRON_TZ"]
+	if name == "" {
+		name = env["TZ"]
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// FormatCrontab writes entries to w as a crontab file which ParseCrontab
+// parses back into the same entries, apart from their line numbers. The
+// entries' schedules and users are aligned in columns. A schedule is written
+// in the form returned by Schedule.String, and commands are written as they
+// are.
+//
+// Before each entry, FormatCrontab writes the assignments needed to change
+// the environment from that of the previous entry to the entry's Env, then
+// the entry's Comments and an annotation holding its Metadata. Since a
+// crontab cannot remove a variable from the environment, a variable which
+// an entry lacks but the previous entry has is assigned the empty string.
+//
+// FormatCrontab returns an error, and writes nothing, if an entry cannot be
+// written: if its schedule is not valid, its command is empty, a field
+// contains a line break, an environment variable's name or a metadata key
+// is not valid, or some entries have a User and others do not.
+func FormatCrontab(w io.Writer, entries []Entry) error {
+	schedules := make([][]string, len(entries))
+	var widths [5]int
+	schedWidth, userWidth := 0, 0
+	for i, e := range entries {
+		if err := checkCrontabEntry(e); err != nil {
+			return fmt.Errorf("entry %d: %s", i, err)
+		}
+		if (e.User == "") != (entries[0].User == "") {
+			return fmt.Errorf("entry %d: some entries have a user and others do not", i)
+		}
+		if e.Reboot {
+			schedules[i] = []string{"@reboot"}
+		} else {
+			schedules[i] = strings.Fields(e.Schedule.String())
+			for j, f := range schedules[i] {
+				if len(f) > widths[j] {
+					widths[j] = len(f)
+				}
+			}
+		}
+		if len(e.User) > userWidth {
+			userWidth = len(e.User)
+		}
+	}
+	for _, w := range widths {
+		schedWidth += w + 1
+	}
+	if schedWidth--; schedWidth < len("@reboot") {
+		schedWidth = len("@reboot")
+	}
+
+	var b strings.Builder
+	var env map[string]string
+	for i, e := range entries {
+		assignments := envChanges(env, e.Env)
+		env = e.Env
+		comments := entryComments(e)
+		if i > 0 && (len(assignments) > 0 || len(comments) > 0) {
+			b.WriteString("\n")
+		}
+		for _, line := range append(assignments, comments...) {
+			b.WriteString(line + "\n")
+		}
+		var line strings.Builder
+		if e.Reboot {
+			line.WriteString(schedules[i][0])
+		} else {
+			for j, f := range schedules[i] {
+				if j > 0 {
+					line.WriteByte(' ')
+				}
+				line.WriteString(f)
+				if j < len(widths)-1 {
+					line.WriteString(strings.Repeat(" ", widths[j]-len(f)))
+				}
+			}
+		}
+		fmt.Fprintf(&b, "%-*s ", schedWidth, line.String())
+		if e.User != "" {
+			fmt.Fprintf(&b, "%-*s ", userWidth, e.User)
+		}
+		b.WriteString(joinCommand(e.Command, e.Stdin) + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// checkCrontabEntry reports why e cannot be written by FormatCrontab, if it
+// cannot.
+func checkCrontabEntry(e Entry) error {
+	if !e.Reboot {
+		if err := e.Schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	if e.Command == "" {
+		return errors.New("empty command")
+	}
+	if strings.ContainsAny(e.User, " \t\r\n") {
+		return fmt.Errorf("invalid user %q", e.User)
+	}
+	if strings.ContainsAny(e.Command, "\r\n") {
+		return errors.New("command contains a line break")
+	}
+	if strings.Contains(e.Stdin, "\r") {
+		return errors.New("standard input contains a carriage return")
+	}
+	if cmd, stdin := splitCommand(joinCommand(e.Command, e.Stdin)); cmd != e.Command || stdin != e.Stdin {
+		return errors.New("command and standard input cannot be written with cron's % escapes")
+	}
+	for _, c := range e.Comments {
+		if strings.ContainsAny(c, "\r\n") {
+			return errors.New("comment contains a line break")
+		}
+		if isMetadata(c) {
+			return fmt.Errorf("comment %q looks like metadata", c)
+		}
+	}
+	for key, value := range e.Metadata {
+		if key == "" || strings.ContainsAny(key, " \t\r\n=\"") {
+			return fmt.Errorf("invalid metadata key %q", key)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("metadata value of %s contains a line break", key)
+		}
+	}
+	for name, value := range e.Env {
+		if name == "" || strings.ContainsAny(name, " \t\r\n=") || strings.HasPrefix(name, "#") {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("value of %s contains a line break", name)
+		}
+	}
+	return nil
+}
+
+// entryComments returns the comment lines written above e: its Comments
+// and then its Metadata, if it has any.
+func entryComments(e Entry) []string {
+	var lines []string
+	for _, text := range e.Comments {
+		lines = append(lines, commentLine(text))
+	}
+	if len(e.Metadata) > 0 {
+		lines = append(lines, formatMetadata(e.Metadata))
+	}
+	return lines
+}
+
+// metadataPrefix begins the text of a comment holding an annotation.
+const metadataPrefix = "cron:"
+
+// isMetadata reports whether text, the text of a comment, is an
+// annotation: it begins with "cron:" and a character other than a space,
+// so that prose such as "cron: runs nightly" is not taken as one.
+func isMetadata(text string) bool {
+	return strings.HasPrefix(text, metadataPrefix) && len(text) > len(metadataPrefix) &&
+		text[len(metadataPrefix)] != ' ' && text[len(metadataPrefix)] != '\t'
+}
+
+// parseMetadata parses text, an annotation, adding its pairs to md.
+func parseMetadata(text string, md map[string]string) error {
+	rest := text[len(metadataPrefix):]
+	for {
+		rest = strings.TrimLeft(rest, " \t")
+		if rest == "" {
+			return nil
+		}
+		i := strings.IndexAny(rest, " \t=")
+		if i <= 0 || rest[i] != '=' {
+			field, _ := splitField(rest)
+			return fmt.Errorf("invalid metadata %q (want key=value)", field)
+		}
+		key := rest[:i]
+		rest = rest[i+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := quotedEnd(rest)
+			if end < 0 {
+				return fmt.Errorf("unterminated quoted value of metadata key %s", key)
+			}
+			v, err := strconv.Unquote(rest[:end])
+			if err != nil {
+				return fmt.Errorf("invalid quoted value of metadata key %s", key)
+			}
+			value, rest = v, rest[end:]
+			if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+				return fmt.Errorf("missing space after quoted value of metadata key %s", key)
+			}
+		} else {
+			value, rest = splitField(rest)
+		}
+		md[key] = value
+	}
+}
+
+// quotedEnd returns the length of the double-quoted string at the start of
+// s, or -1 if it is unterminated.
+func quotedEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// formatMetadata returns an annotation comment holding md, with its keys
+// sorted.
+func formatMetadata(md map[string]string) string {
+	keys := make([]string, 0, len(md))
+	for key := range md {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString("# " + metadataPrefix)
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		value := md[key]
+		if q := strconv.Quote(value); value == "" || strings.ContainsAny(value, " \t") || q[1:len(q)-1] != value {
+			value = q
+		}
+		b.WriteString(key + "=" + value)
+	}
+	return b.String()
+}
+
+// envChanges returns the assignments, sorted by name, which change the
+// environment from prev to env.
+func envChanges(prev, env map[string]string) []string {
+	var names []string
+	for name, value := range env {
+		if v, ok := prev[name]; !ok || v != value {
+			names = append(names, name)
+		}
+	}
+	for name := range prev {
+		if _, ok := env[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	assignments := make([]string, len(names))
+	for i, name := range names {
+		assignments[i] = formatEnvAssignment(name, env[name])
+	}
+	return assignments
+}
+
+// formatEnvAssignment formats an assignment which parseEnvAssignment parses
+// as name and value. Values are quoted if they are empty, have leading or
+// trailing space, or begin with a quote, since parseEnvAssignment would
+// otherwise change them.
+func formatEnvAssignment(name, value string) string {
+	if value == "" || strings.TrimSpace(value) != value || value[0] == '"' || value[0] == '\'' {
+		value = `"` + value + `"`
+	}
+	return name + "=" + value
+}
+
+// isSystemCrontab guesses whether lines are a system crontab, as described
+// for DetectCrontab.
+func isSystemCrontab(lines []string) bool {
+	allRoot := true
+	var n int
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			var user, command bool
+			for _, word := range strings.Fields(strings.TrimLeft(line, "#")) {
+				switch strings.ToLower(word) {
+				case "user", "user-name", "username":
+					user = true
+				case "command", "cmd":
+					command = true
+				}
+			}
+			if user && command {
+				return true
+			}
+			continue
+		}
+		e, ok, err := parseCrontabEntry(line, false)
+		if err != nil || !ok {
+			continue
+		}
+		n++
+		if user, cmd := splitField(e.Command); user != "root" || cmd == "" {
+			allRoot = false
+		}
+	}
+	return n > 0 && allRoot
+}
+
+// parseCrontabEntry parses a line of a crontab file, which has a user
+// field if system is true. It reports false if the line is not an entry.
+func parseCrontabEntry(line string, system bool) (Entry, bool, error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return Entry{}, false, nil
+	}
+	if _, _, ok := parseEnvAssignment(line); ok {
+		return Entry{}, false, nil
+	}
+	var e Entry
+	var cmd string
+	if strings.HasPrefix(line, "@") {
+		var name string
+		name, cmd = splitField(line)
+		if name == "@reboot" {
+			e.Reboot = true
+		} else {
+			if expr, ok := crontabSchedules[name]; ok {
+				name = expr
+			}
+			s, err := Parse(name)
+			if err != nil {
+				return Entry{}, false, err
+			}
+			e.Schedule = s
+		}
+	} else {
+		fields := make([]string, 5)
+		cmd = line
+		for i := range fields {
+			fields[i], cmd = splitField(cmd)
+		}
+		if fields[4] == "" {
+			return Entry{}, false, errors.New("expected five schedule fields and a command")
+		}
+		s, err := Parse(strings.Join(fields, " "))
+		if err != nil {
+			return Entry{}, false, err
+		}
+		e.Schedule = s
+	}
+	if system {
+		e.User, cmd = splitField(cmd)
+		if e.User == "" {
+			return Entry{}, false, errors.New("missing user")
+		}
+	}
+	if cmd == "" {
+		return Entry{}, false, errors.New("missing command")
+	}
+	e.Command, e.Stdin = splitCommand(cmd)
+	return e, true, nil
+}
+
+// splitCommand splits cmd, the command field of an entry, into the command
+// and its standard input, undoing cron's % escapes in the same way as
+// Vixie cron.
+func splitCommand(cmd string) (command, stdin string) {
+	var b strings.Builder
+	for i := 0; i < len(cmd); i++ {
+		switch c := cmd[i]; {
+		case c == '\\' && i+1 < len(cmd):
+			// A backslash escapes the next character, but is removed
+			// only if that is a %.
+			i++
+			if cmd[i] != '%' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(cmd[i])
+		case c == '%':
+			return b.String(), unescapeStdin(cmd[i+1:])
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), ""
+}
+
+// unescapeStdin returns the standard input given by s, the text after the
+// first unescaped % of an entry. Cron turns each % into a newline, unless
+// it follows a backslash, which is removed.
+func unescapeStdin(s string) string {
+	var b strings.Builder
+	escaped := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if escaped {
+			if c != '%' {
+				b.WriteByte('\\')
+			}
+		} else if c == '%' {
+			c = '\n'
+		}
+		if escaped = c == '\\'; !escaped {
+			b.WriteByte(c)
+		}
+	}
+	if escaped {
+		b.WriteByte('\\')
+	}
+	return b.String()
+}
+
+// joinCommand returns the command field of an entry which runs command
+// with the given standard input, escaping each % in them. Not every
+// command and standard input can be written: splitCommand returns
+// different ones if a backslash is followed by a % or a newline.
+func joinCommand(command, stdin string) string {
+	s := strings.Replace(command, "%", `\%`, -1)
+	if stdin != "" {
+		s += "%" + strings.NewReplacer("%", `\%`, "\n", "%").Replace(stdin)
+	}
+	return s
+}
+
+// splitField splits s, which has no leading space, into its first
+// space-separated field and the rest, without its leading space.
+func splitField(s string) (field, rest string) {
+	i := strings.IndexAny(s, " \t")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], strings.TrimLeft(s[i:], " \t")
+}
+
+// parseEnvAssignment parses line, which has no leading or trailing space,
+// as an environment assignment. It reports false if line is not one. As in
+// Vixie cron, a value enclosed in matching single or double quotes is
+// unquoted.
+func parseEnvAssignment(line string) (name, value string, ok bool) {
+	i := strings.IndexAny(line, " \t=")
+	if i <= 0 {
+		return "", "", false
+	}
+	name = line[:i]
+	rest := strings.TrimLeft(line[i:], " \t")
+	if !strings.HasPrefix(rest, "=") {
+		return "", "", false
+	}
+	value = strings.TrimLeft(rest[1:], " \t")
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return name, value, true
+}
@@ -0,0 +1,659 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseCrontab(t *testing.T) {
+	const crontab = `# m h dom mon dow command
+SHELL=/bin/bash
+MAILTO = "ops@example.com"
+
+0 3 * * *	/usr/local/bin/backup --full   >/dev/null 2>&1
+  */15 9-17 * * MON-FRI  check-queue
+@hourly rotate-logs
+@yearly   happy-new-year
+@reboot start-agent -v
+`
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type entry struct {
+		Line     int
+		Schedule string
+		Command  string
+	}
+	var got []entry
+	for _, e := range entries {
+		got = append(got, entry{e.Line, e.Recurrence().(fmt.Stringer).String(), e.Command})
+	}
+	want := []entry{
+		{5, "0 3 * * *", "/usr/local/bin/backup --full   >/dev/null 2>&1"},
+		{6, "*/15 9-17 * * MON-FRI", "check-queue"},
+		{7, "0 * * * *", "rotate-logs"},
+		{8, "0 0 1 JAN *", "happy-new-year"},
+		{9, "@reboot", "start-agent -v"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("entries (-got, +want):\n%s", diff)
+	}
+	if !entries[4].Reboot || entries[4].Schedule.Valid() {
+		t.Errorf("@reboot entry = %+v", entries[4])
+	}
+}
+
+func TestParseCrontabEnvComments(t *testing.T) {
+	const crontab = `# Header, not attached to an entry.
+
+SHELL=/bin/bash
+# Nightly backup.
+#
+#  Keeps a week of copies.
+0 3 * * * backup
+MAILTO=""
+# 5 * * * * x
+@hourly check
+`
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Entry{
+		{
+			Line:     7,
+			Schedule: mustParse(t, "0 3 * * *"),
+			Command:  "backup",
+			Env:      map[string]string{"SHELL": "/bin/bash"},
+			Comments: []string{"Nightly backup.", "", " Keeps a week of copies."},
+		},
+		{
+			Line:     10,
+			Schedule: mustParse(t, "@hourly"),
+			Command:  "check",
+			Env:      map[string]string{"SHELL": "/bin/bash", "MAILTO": ""},
+			Comments: []string{"5 * * * * x"},
+		},
+	}
+	if diff := cmp.Diff(entries, want, cmp.AllowUnexported(Schedule{})); diff != "" {
+		t.Errorf("entries (-got, +want):\n%s", diff)
+	}
+}
+
+func TestCrontabEnvironment(t *testing.T) {
+	const crontab = `MAILTO=ops@example.com
+0 1 * * * plain
+TZ=Europe/London
+CRON_TZ=America/New_York
+SHELL=/bin/bash
+30 2 * * * gap
+30 1 * * * fold
+*/30 * * * * fold-wildcard
+MAILTO=""
+CRON_TZ=
+0 0 * * * london
+`
+	entries, err := ParseCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ny := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+	type env struct {
+		Shell    string
+		MailTo   string
+		MailSet  bool
+		Location *time.Location
+	}
+	var got []env
+	for _, e := range entries {
+		to, ok := e.MailTo()
+		got = append(got, env{e.Shell(), to, ok, e.Location})
+	}
+	want := []env{
+		{"/bin/sh", "ops@example.com", true, nil},
+		{"/bin/bash", "ops@example.com", true, ny},
+		{"/bin/bash", "ops@example.com", true, ny},
+		{"/bin/bash", "ops@example.com", true, ny},
+		{"/bin/bash", "", true, london},
+	}
+	if diff := cmp.Diff(got, want, cmp.Comparer(func(a, b *time.Location) bool { return a.String() == b.String() })); diff != "" {
+		t.Errorf("environments (-got, +want):\n%s", diff)
+	}
+	if _, ok := entries[0].Recurrence().(Schedule); !ok {
+		t.Errorf("Recurrence() without a time zone = %T; want Schedule", entries[0].Recurrence())
+	}
+
+	// Daylight saving time changes are handled as by Vixie cron.
+	utc := func(month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, time.UTC)
+	}
+	for _, tt := range []struct {
+		entry int
+		from  time.Time
+		want  []time.Time
+	}{
+		// 02:30 is skipped on March 8, so the job runs at 03:00 EDT.
+		{1, utc(3, 8, 0, 0), []time.Time{utc(3, 8, 7, 0), utc(3, 9, 6, 30)}},
+		// 01:30 happens twice on November 1, and the job runs once.
+		{2, utc(11, 1, 0, 0), []time.Time{utc(11, 1, 5, 30), utc(11, 2, 6, 30)}},
+		{3, utc(11, 1, 4, 59), []time.Time{utc(11, 1, 5, 0), utc(11, 1, 5, 30), utc(11, 1, 6, 0), utc(11, 1, 6, 30)}},
+	} {
+		r := entries[tt.entry].Recurrence()
+		var got []time.Time
+		for t := tt.from; len(got) < len(tt.want); {
+			t = r.Next(t)
+			got = append(got, t.UTC())
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("%s: occurrences (-got, +want):\n%s", entries[tt.entry].Command, diff)
+		}
+	}
+
+	_, err = ParseCrontab(strings.NewReader("0 1 * * * a\nCRON_TZ=Mars/Olympus_Mons\n"))
+	if ce, ok := err.(*CrontabError); !ok || ce.Line != 2 {
+		t.Errorf("with unknown time zone, got error %v; want one for line 2", err)
+	}
+}
+
+func TestFormatCrontab(t *testing.T) {
+	entries := []Entry{
+		{
+			Schedule: mustParse(t, "0 3 * * *"),
+			Command:  "/usr/local/bin/backup --full",
+			Env:      map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"},
+			Comments: []string{"Nightly backup.", ""},
+		},
+		{
+			Schedule: mustParse(t, "*/15 9-17 * * 1-5"),
+			Command:  "check-queue",
+			Env:      map[string]string{"SHELL": "/bin/bash", "MAILTO": "ops@example.com"},
+		},
+		{
+			Reboot:  true,
+			Command: "start-agent",
+			Env:     map[string]string{"SHELL": "/bin/bash", "GREETING": " hi "},
+		},
+		{
+			Schedule: mustParse(t, "30 12 1 JAN,JUL *"),
+			Command:  "report",
+			Env:      map[string]string{"SHELL": "/bin/bash", "GREETING": " hi "},
+		},
+	}
+	const want = `MAILTO=ops@example.com
+SHELL=/bin/bash
+# Nightly backup.
+#
+0    3    * *       *       /usr/local/bin/backup --full
+*/15 9-17 * *       MON-FRI check-queue
+
+GREETING=" hi "
+MAILTO=""
+@reboot                     start-agent
+30   12   1 JAN,JUL *       report
+`
+	var b strings.Builder
+	if err := FormatCrontab(&b, entries); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); got != want {
+		t.Errorf("FormatCrontab: got\n%s\nwant\n%s", got, want)
+	}
+
+	// Parsing the result gives back the same entries, except that MAILTO
+	// is set to the empty string rather than removed.
+	got, err := ParseCrontab(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range got {
+		got[i].Line = 0
+	}
+	entries[2].Env["MAILTO"] = ""
+	entries[3].Env["MAILTO"] = ""
+	if diff := cmp.Diff(got, entries, cmp.AllowUnexported(Schedule{})); diff != "" {
+		t.Errorf("parsed result (-got, +want):\n%s", diff)
+	}
+
+	b.Reset()
+	users := []Entry{
+		{Schedule: mustParse(t, "17 * * * *"), User: "root", Command: "run-parts /etc/cron.hourly"},
+		{Reboot: true, User: "www-data", Command: "warm-cache"},
+	}
+	if err := FormatCrontab(&b, users); err != nil {
+		t.Fatal(err)
+	}
+	const wantUsers = `17 * * * * root     run-parts /etc/cron.hourly
+@reboot    www-data warm-cache
+`
+	if got := b.String(); got != wantUsers {
+		t.Errorf("FormatCrontab with users: got\n%s\nwant\n%s", got, wantUsers)
+	}
+}
+
+func TestFormatCrontabErrors(t *testing.T) {
+	daily := mustParse(t, "@daily")
+	for _, tt := range []struct {
+		entries []Entry
+		err     string
+	}{
+		{[]Entry{{Command: "x"}}, "entry 0: zero cron schedule (not created by Parse or a Builder)"},
+		{[]Entry{{Schedule: daily, Command: "x"}, {Schedule: daily}}, "entry 1: empty command"},
+		{[]Entry{{Schedule: daily, Command: "x\ny"}}, "entry 0: command contains a line break"},
+		{[]Entry{{Schedule: daily, Command: "x", Comments: []string{"a\nb"}}}, "entry 0: comment contains a line break"},
+		{[]Entry{{Schedule: daily, Command: "x", User: "a b"}}, `entry 0: invalid user "a b"`},
+		{[]Entry{{Schedule: daily, Command: "x", Env: map[string]string{"A B": "c"}}}, `entry 0: invalid environment variable name "A B"`},
+		{[]Entry{{Schedule: daily, Command: "x", Env: map[string]string{"A": "b\nc"}}}, "entry 0: value of A contains a line break"},
+		{[]Entry{{Schedule: daily, Command: "x", User: "root"}, {Schedule: daily, Command: "y"}}, "entry 1: some entries have a user and others do not"},
+	} {
+		var b strings.Builder
+		err := FormatCrontab(&b, tt.entries)
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("FormatCrontab(%+v): got error %v; want %q", tt.entries, err, tt.err)
+		}
+		if b.Len() > 0 {
+			t.Errorf("FormatCrontab(%+v) wrote %q despite the error", tt.entries, b.String())
+		}
+	}
+}
+
+func TestCrontabEdit(t *testing.T) {
+	const crontab = `# Maintained by hand; keep the columns lined up.
+SHELL=/bin/bash
+
+# Nightly backup.
+0    3  *  *  *    /usr/local/bin/backup   # trailing comment is part of the command
+*/15 9-17 * * Mon-Fri  check-queue
+@daily	rotate-logs
+
+# Obsolete.
+0 4 * * * old-job
+30 5 1 * * monthly`
+	c, err := ReadCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func() string {
+		t.Helper()
+		var b strings.Builder
+		if _, err := c.WriteTo(&b); err != nil {
+			t.Fatal(err)
+		}
+		return b.String()
+	}
+	if got := write(); got != crontab {
+		t.Errorf("unmodified Crontab written as\n%s\nwant\n%s", got, crontab)
+	}
+
+	entries := c.Entries()
+	// Change only the hour of the backup.
+	e := entries[0]
+	e.Schedule = mustParse(t, "0 23 * * *")
+	if err := c.Set(0, e); err != nil {
+		t.Fatal(err)
+	}
+	// The same times, written differently, leave the line alone.
+	e = entries[1]
+	e.Schedule = mustParse(t, "0,15,30,45 9-17 * * 1-5")
+	e.Command = "check-queue --verbose"
+	if err := c.Set(1, e); err != nil {
+		t.Fatal(err)
+	}
+	e = entries[2]
+	e.Schedule = mustParse(t, "0 12 * * *")
+	if err := c.Set(2, e); err != nil {
+		t.Fatal(err)
+	}
+	c.Remove(3)
+	if err := c.Add(Entry{Reboot: true, Command: "start-agent", Comments: []string{"Added."}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(Entry{
+		Schedule: mustParse(t, "0 0 1 1 *"),
+		Command:  "happy-new-year",
+		Env:      map[string]string{"SHELL": "/bin/bash", "MAILTO": ""},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	const want = `# Maintained by hand; keep the columns lined up.
+SHELL=/bin/bash
+
+# Nightly backup.
+0    23 *  *  *    /usr/local/bin/backup   # trailing comment is part of the command
+*/15 9-17 * * Mon-Fri  check-queue --verbose
+0 12 * * *	rotate-logs
+
+30 5 1 * * monthly
+
+# Added.
+@reboot start-agent
+
+MAILTO=""
+0 0 1 JAN * happy-new-year
+`
+	if got := write(); got != want {
+		t.Errorf("edited Crontab written as\n%s\nwant\n%s", got, want)
+	}
+
+	// The entries match the text.
+	parsed, err := ParseCrontab(strings.NewReader(want))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(c.Entries(), parsed, cmp.AllowUnexported(Schedule{})); diff != "" {
+		t.Errorf("entries (-got, +want):\n%s", diff)
+	}
+
+	if err := c.Add(Entry{Schedule: mustParse(t, "@daily"), User: "root", Command: "x"}); err == nil {
+		t.Error("Add with a user in a non-system crontab succeeded")
+	}
+	if err := c.Set(0, Entry{Schedule: mustParse(t, "@daily")}); err == nil {
+		t.Error("Set with an empty command succeeded")
+	}
+}
+
+func TestSystemCrontabEdit(t *testing.T) {
+	const crontab = "17 *\t* * *\troot    cd / && run-parts /etc/cron.hourly\n"
+	c, err := ReadCrontab(strings.NewReader(crontab), WithCrontabFormat(SystemCrontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := c.Entries()[0]
+	e.User = "nobody"
+	e.Schedule = mustParse(t, "5 * * * *")
+	if err := c.Set(0, e); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(Entry{Reboot: true, User: "www-data", Command: "warm-cache"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Add(Entry{Reboot: true, Command: "warm-cache"}); err == nil {
+		t.Error("Add without a user in a system crontab succeeded")
+	}
+	var b strings.Builder
+	c.WriteTo(&b)
+	const want = "5  *\t* * *\tnobody  cd / && run-parts /etc/cron.hourly\n" +
+		"@reboot www-data warm-cache\n"
+	if got := b.String(); got != want {
+		t.Errorf("edited system Crontab written as\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCrontabMetadata(t *testing.T) {
+	const crontab = `# Nightly backup.
+# cron:name=nightly-backup owner=dba
+# cron:desc="Back up \"main\"" owner=ops
+0 3 * * * backup
+# cron: is not an annotation here.
+@hourly check
+# cron:name=report
+
+0 9 * * * report
+`
+	c, err := ReadCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := c.Entries()
+	want := []Entry{
+		{
+			Line:     4,
+			Schedule: mustParse(t, "0 3 * * *"),
+			Command:  "backup",
+			Comments: []string{"Nightly backup."},
+			Metadata: map[string]string{"name": "nightly-backup", "owner": "ops", "desc": `Back up "main"`},
+		},
+		{
+			Line:     6,
+			Schedule: mustParse(t, "@hourly"),
+			Command:  "check",
+			Comments: []string{"cron: is not an annotation here."},
+		},
+		{
+			Line:     9,
+			Schedule: mustParse(t, "0 9 * * *"),
+			Command:  "report",
+		},
+	}
+	if diff := cmp.Diff(entries, want, cmp.AllowUnexported(Schedule{})); diff != "" {
+		t.Errorf("entries (-got, +want):\n%s", diff)
+	}
+
+	var b strings.Builder
+	if err := FormatCrontab(&b, entries); err != nil {
+		t.Fatal(err)
+	}
+	const wantFormat = `# Nightly backup.
+# cron:desc="Back up \"main\"" name=nightly-backup owner=ops
+0 3 * * * backup
+
+# cron: is not an annotation here.
+0 * * * * check
+0 9 * * * report
+`
+	if got := b.String(); got != wantFormat {
+		t.Errorf("FormatCrontab wrote\n%s\nwant\n%s", got, wantFormat)
+	}
+
+	e := entries[0]
+	e.Metadata = map[string]string{"name": "nightly-backup", "owner": ""}
+	if err := c.Set(0, e); err != nil {
+		t.Fatal(err)
+	}
+	e = entries[1]
+	e.Metadata = map[string]string{"name": "check"}
+	if err := c.Set(1, e); err != nil {
+		t.Fatal(err)
+	}
+	c.Remove(0)
+	b.Reset()
+	if _, err := c.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+	const wantEdit = `# cron: is not an annotation here.
+# cron:name=check
+@hourly check
+# cron:name=report
+
+0 9 * * * report
+`
+	if got := b.String(); got != wantEdit {
+		t.Errorf("edited Crontab written as\n%s\nwant\n%s", got, wantEdit)
+	}
+	if got := c.Entries()[1].Line; got != 6 {
+		t.Errorf("after edits, last entry is on line %d; want 6", got)
+	}
+
+	err = FormatCrontab(&b, []Entry{{Reboot: true, Command: "x", Metadata: map[string]string{"a b": "c"}}})
+	if err == nil || !strings.Contains(err.Error(), `invalid metadata key "a b"`) {
+		t.Errorf("FormatCrontab with invalid metadata key: got error %v", err)
+	}
+}
+
+func TestParseCrontabErrors(t *testing.T) {
+	for _, tt := range []struct {
+		crontab string
+		line    int
+		err     string
+	}{
+		{"0 3 * * * backup\n0 3 * *\n", 2, "expected five schedule fields and a command"},
+		{"0 3 * * *\n", 1, "missing command"},
+		{"@daily\n", 1, "missing command"},
+		{"# ok\n@fortnightly backup\n", 2, "unrecognized cron schedule name"},
+		{"60 3 * * * backup\n", 1, "invalid value 60 for the minute field"},
+		{"backup\n", 1, "expected five schedule fields"},
+		{"# cron:name\n0 3 * * * backup\n", 1, `invalid metadata "name"`},
+		{"# cron:desc=\"a b\n0 3 * * * backup\n", 1, "unterminated quoted value"},
+	} {
+		_, err := ParseCrontab(strings.NewReader(tt.crontab))
+		var ce *CrontabError
+		if !errors.As(err, &ce) {
+			t.Errorf("ParseCrontab(%q): got error %v; want a *CrontabError", tt.crontab, err)
+			continue
+		}
+		if ce.Line != tt.line || !strings.Contains(ce.Error(), tt.err) {
+			t.Errorf("ParseCrontab(%q): got error %q; want line %d containing %q", tt.crontab, ce, tt.line, tt.err)
+		}
+	}
+}
+
+func TestParseSystemCrontab(t *testing.T) {
+	const debian = `SHELL=/bin/sh
+
+# m h dom mon dow user	command
+17 *	* * *	root    cd / && run-parts --report /etc/cron.hourly
+@reboot	www-data	/usr/local/bin/warm-cache
+`
+	const rootOnly = `0 4 * * * root /usr/sbin/logrotate /etc/logrotate.conf
+30 4 * * * root	/usr/bin/updatedb
+`
+	const user = `0 4 * * * /usr/sbin/logrotate /etc/logrotate.conf
+30 4 * * * root
+`
+	type entry struct {
+		Line    int
+		User    string
+		Command string
+	}
+	for _, tt := range []struct {
+		name    string
+		crontab string
+		format  CrontabFormat
+		want    []entry
+		wantErr string
+	}{
+		{
+			name:    "system",
+			crontab: debian,
+			format:  SystemCrontab,
+			want: []entry{
+				{4, "root", "cd / && run-parts --report /etc/cron.hourly"},
+				{5, "www-data", "/usr/local/bin/warm-cache"},
+			},
+		},
+		{
+			name:    "detect header",
+			crontab: debian,
+			format:  DetectCrontab,
+			want: []entry{
+				{4, "root", "cd / && run-parts --report /etc/cron.hourly"},
+				{5, "www-data", "/usr/local/bin/warm-cache"},
+			},
+		},
+		{
+			name:    "detect root",
+			crontab: rootOnly,
+			format:  DetectCrontab,
+			want: []entry{
+				{1, "root", "/usr/sbin/logrotate /etc/logrotate.conf"},
+				{2, "root", "/usr/bin/updatedb"},
+			},
+		},
+		{
+			name:    "detect user",
+			crontab: user,
+			format:  DetectCrontab,
+			want: []entry{
+				{1, "", "/usr/sbin/logrotate /etc/logrotate.conf"},
+				{2, "", "root"},
+			},
+		},
+		{
+			name:    "missing command",
+			crontab: user,
+			format:  SystemCrontab,
+			wantErr: "line 2: missing command",
+		},
+	} {
+		entries, err := ParseCrontab(strings.NewReader(tt.crontab), WithCrontabFormat(tt.format))
+		if tt.wantErr != "" {
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("%s: got error %v; want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var got []entry
+		for _, e := range entries {
+			got = append(got, entry{e.Line, e.User, e.Command})
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("%s: entries (-got, +want):\n%s", tt.name, diff)
+		}
+	}
+}
+
+func TestParseEnvAssignment(t *testing.T) {
+	for _, tt := range []struct {
+		line  string
+		name  string
+		value string
+		ok    bool
+	}{
+		{"PATH=/usr/bin:/bin", "PATH", "/usr/bin:/bin", true},
+		{"MAILTO = ops@example.com", "MAILTO", "ops@example.com", true},
+		{`MAILTO=""`, "MAILTO", "", true},
+		{`GREETING='hello world'`, "GREETING", "hello world", true},
+		{`GREETING="hello'`, "GREETING", `"hello'`, true},
+		{"EMPTY=", "EMPTY", "", true},
+		{"0 3 * * * FOO=bar cmd", "", "", false},
+		{"=value", "", "", false},
+		{"@daily x=1", "", "", false},
+	} {
+		name, value, ok := parseEnvAssignment(tt.line)
+		if name != tt.name || value != tt.value || ok != tt.ok {
+			t.Errorf("parseEnvAssignment(%q) = %q, %q, %t; want %q, %q, %t",
+				tt.line, name, value, ok, tt.name, tt.value, tt.ok)
+		}
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	for _, tt := range []struct {
+		cmd     string
+		command string
+		stdin   string
+	}{
+		{"backup", "backup", ""},
+		{`date +\%Y-\%m-\%d`, "date +%Y-%m-%d", ""},
+		{"mail -s hi ops%Dear ops,%%The backup failed.%", "mail -s hi ops", "Dear ops,\n\nThe backup failed.\n"},
+		{`cat%100\% done`, "cat", "100% done"},
+		{`echo a\\b`, `echo a\\b`, ""},
+		{`echo \\%in`, `echo \\`, "in"},
+		{`echo \\\%`, `echo \\%`, ""},
+		{`cat%a\\%b\`, "cat", `a\%b\`},
+		{"cat%", "cat", ""},
+	} {
+		command, stdin := splitCommand(tt.cmd)
+		if command != tt.command || stdin != tt.stdin {
+			t.Errorf("splitCommand(%q) = %q, %q; want %q, %q", tt.cmd, command, stdin, tt.command, tt.stdin)
+		}
+		if tt.cmd == "cat%" {
+			continue
+		}
+		if got := joinCommand(command, stdin); got != tt.cmd {
+			t.Errorf("joinCommand(%q, %q) = %q; want %q", command, stdin, got, tt.cmd)
+		}
+	}
+
+	// Commands and standard input which cannot be written are rejected.
+	for _, e := range []Entry{
+		{Command: `echo \%`},
+		{Command: `echo \`, Stdin: "x"},
+		{Command: "cat", Stdin: "a\\\nb"},
+	} {
+		e.Reboot = true
+		if err := FormatCrontab(new(strings.Builder), []Entry{e}); err == nil {
+			t.Errorf("FormatCrontab with command %q and stdin %q: got nil error", e.Command, e.Stdin)
+		}
+	}
+}
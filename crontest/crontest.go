@@ -0,0 +1,202 @@
+// Package crontest provides a fake clock for testing code which uses a
+// cron.Runner, so that tests can move time forward and check which jobs ran
+// without sleeping:
+//
+//	r, clock := crontest.NewRunner(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+//	r.AddFunc(daily, func() { runs++ })
+//	r.Start()
+//	defer r.Stop()
+//	clock.Advance(48 * time.Hour)
+//	// runs == 2
+//
+// It also provides RandomExpr, which generates cron expressions for
+// property-based tests.
+package crontest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cespare/cron"
+)
+
+// A Clock is a cron.Clock whose time changes only when Advance is called.
+// The zero value is not usable; use NewClock or NewRunner to create one.
+type Clock struct {
+	runner *cron.Runner // nil unless created by NewRunner
+
+	mu      sync.Mutex
+	now     time.Time
+	timers  []*timer      // those which have neither fired nor been stopped
+	created chan struct{} // receives a value when a timer is created
+}
+
+// NewClock returns a Clock whose time is now.
+func NewClock(now time.Time) *Clock {
+	return &Clock{now: now, created: make(chan struct{}, 1)}
+}
+
+// NewRunner returns a cron.Runner configured by the given options which
+// uses a new Clock, starting at now, and the Clock. Advancing the Clock
+// runs the Runner's jobs synchronously, as described at Advance.
+func NewRunner(now time.Time, opts ...cron.RunnerOption) (*cron.Runner, *Clock) {
+	c := NewClock(now)
+	opts = append(opts[:len(opts):len(opts)], cron.WithClock(c))
+	c.runner = cron.NewRunner(opts...)
+	return c.runner, c
+}
+
+// Now returns the Clock's current time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTimer returns a Timer which fires when the Clock is advanced by at
+// least d.
+func (c *Clock) NewTimer(d time.Duration) cron.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &timer{
+		clock: c,
+		c:     make(chan time.Time, 1),
+		when:  c.now.Add(d),
+		done:  make(chan struct{}),
+	}
+	c.timers = append(c.timers, t)
+	select {
+	case c.created <- struct{}{}:
+	default:
+	}
+	return t
+}
+
+// Advance moves the Clock's time forward by d, firing the timers which
+// come due in order. Each timer fires at the time it is due.
+//
+// If the Clock belongs to a Runner created by NewRunner, then after each
+// timer fires, Advance waits for the Runner to handle it, for the jobs it
+// started to finish, and for the Runner to set its next timer. So when
+// Advance returns, every job due by the new time has run. Advance must not
+// be called concurrently with the Runner's Start or Stop methods, and the
+// Runner's jobs must not wait for the Clock to advance.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	for {
+		c.settle()
+		t := c.due(target)
+		if t == nil {
+			break
+		}
+		t.c <- t.when
+		if c.runner != nil {
+			<-t.done // the Runner stops each timer after handling it
+		}
+	}
+	c.mu.Lock()
+	if target.After(c.now) {
+		c.now = target
+	}
+	c.mu.Unlock()
+}
+
+// due removes and returns the earliest timer due by target, advancing the
+// time to when it is due, or returns nil if there is no such timer.
+func (c *Clock) due(target time.Time) *timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := -1
+	for j, t := range c.timers {
+		if !t.when.After(target) && (i < 0 || t.when.Before(c.timers[i].when)) {
+			i = j
+		}
+	}
+	if i < 0 {
+		return nil
+	}
+	t := c.timers[i]
+	c.removeLocked(t)
+	t.fired = true
+	if t.when.After(c.now) {
+		c.now = t.when
+	}
+	return t
+}
+
+// settle waits until the Runner, if any, is idle: its jobs have finished
+// and, if it has a next occurrence to wait for, it has set a timer.
+func (c *Clock) settle() {
+	if c.runner == nil {
+		return
+	}
+	for {
+		c.runner.Wait(context.Background())
+		if !c.waiting() {
+			return
+		}
+		c.mu.Lock()
+		n := len(c.timers)
+		c.mu.Unlock()
+		if n > 0 {
+			return
+		}
+		select {
+		case <-c.created:
+		case <-time.After(10 * time.Millisecond):
+			// Check again in case the Runner stopped.
+		}
+	}
+}
+
+// waiting reports whether the Runner is running with a job to run later.
+func (c *Clock) waiting() bool {
+	st := c.runner.Stats()
+	if !st.Running {
+		return false
+	}
+	for _, info := range st.Jobs {
+		if !info.Next.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Clock) removeLocked(t *timer) bool {
+	for i, t1 := range c.timers {
+		if t1 == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type timer struct {
+	clock *Clock
+	c     chan time.Time
+	when  time.Time
+
+	// The following are guarded by clock.mu.
+	fired   bool
+	stopped bool
+	done    chan struct{} // closed by Stop
+}
+
+func (t *timer) C() <-chan time.Time { return t.c }
+
+func (t *timer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	if t.stopped {
+		return false
+	}
+	t.stopped = true
+	close(t.done)
+	t.clock.removeLocked(t)
+	return !t.fired
+}
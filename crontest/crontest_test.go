@@ -0,0 +1,92 @@
+package crontest
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cespare/cron"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewClock(start)
+	t1 := c.NewTimer(time.Hour)
+	t2 := c.NewTimer(2 * time.Hour)
+	t3 := c.NewTimer(3 * time.Hour)
+	if !t3.Stop() {
+		t.Error("Stop() on pending timer = false")
+	}
+	c.Advance(90 * time.Minute)
+	if got, want := c.Now(), start.Add(90*time.Minute); !got.Equal(want) {
+		t.Errorf("after Advance, Now() = %s; want %s", got, want)
+	}
+	select {
+	case got := <-t1.C():
+		if want := start.Add(time.Hour); !got.Equal(want) {
+			t.Errorf("timer fired with %s; want %s", got, want)
+		}
+	default:
+		t.Error("due timer did not fire")
+	}
+	if t1.Stop() {
+		t.Error("Stop() on fired timer = true")
+	}
+	select {
+	case <-t2.C():
+		t.Error("timer fired early")
+	default:
+	}
+	c.Advance(2 * time.Hour)
+	select {
+	case <-t2.C():
+	default:
+		t.Error("due timer did not fire")
+	}
+	select {
+	case <-t3.C():
+		t.Error("stopped timer fired")
+	default:
+	}
+}
+
+func TestRunner(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r, clock := NewRunner(start)
+	daily, err := cron.Parse("0 3 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var runs []time.Time
+	r.Add(daily, func(ctx context.Context) error {
+		runs = append(runs, clock.Now())
+		return nil
+	})
+	var quarters int32
+	r.AddFunc(cron.EveryFrom(start, 15*time.Minute), func() { atomic.AddInt32(&quarters, 1) })
+	r.Start()
+	defer r.Stop()
+
+	clock.Advance(48 * time.Hour)
+	want := []time.Time{
+		time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(runs, want); diff != "" {
+		t.Errorf("daily runs (-got, +want):\n%s", diff)
+	}
+	if got := atomic.LoadInt32(&quarters); got != 48*4 {
+		t.Errorf("after 48h, the 15-minute job ran %d times; want %d", got, 48*4)
+	}
+
+	clock.Advance(time.Hour)
+	if len(runs) != 2 {
+		t.Errorf("after 49h, the daily job ran %d times; want 2", len(runs))
+	}
+	clock.Advance(2 * time.Hour)
+	if len(runs) != 3 {
+		t.Errorf("after 51h, the daily job ran %d times; want 3", len(runs))
+	}
+}
@@ -0,0 +1,106 @@
+package crontest
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// An exprField describes a field of a cron expression for RandomExpr.
+type exprField struct {
+	min, max int
+	names    []string // the names of the values, starting at min, if any
+}
+
+var exprFields = [...]exprField{
+	{min: 0, max: 59},
+	{min: 0, max: 23},
+	{min: 1, max: 31},
+	{min: 1, max: 12, names: []string{
+		"january", "february", "march", "april", "may", "june",
+		"july", "august", "september", "october", "november", "december",
+	}},
+	{min: 0, max: 6, names: []string{
+		"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday",
+	}},
+}
+
+var namedSchedules = []string{"@monthly", "@weekly", "@daily", "@hourly"}
+
+// RandomExpr returns a random cron expression which cron.Parse accepts,
+// using r as the source of randomness, for property-based tests of code
+// which handles schedules. The expressions cover the syntax which Parse
+// accepts: named schedules such as "@daily", and fields holding lists of
+// values, wildcards (*), and ranges (including ranges which wrap around the
+// end of the field, such as 22-2 for hours), with or without steps. Months
+// and days of the week are written as numbers or as names, which are
+// abbreviated and capitalized in various ways.
+//
+// Some of the expressions never fire, such as "0 0 31 FEB *".
+func RandomExpr(r *rand.Rand) string {
+	if r.Intn(20) == 0 {
+		return namedSchedules[r.Intn(len(namedSchedules))]
+	}
+	fields := make([]string, len(exprFields))
+	for i, f := range exprFields {
+		fields[i] = f.random(r)
+	}
+	return strings.Join(fields, " ")
+}
+
+// random returns a random field, which is usually a single element but is
+// sometimes a list.
+func (f exprField) random(r *rand.Rand) string {
+	n := 1
+	if r.Intn(4) == 0 {
+		n += 1 + r.Intn(3)
+	}
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = f.randomPart(r)
+	}
+	return strings.Join(parts, ",")
+}
+
+// randomPart returns a random element of a list in the field.
+func (f exprField) randomPart(r *rand.Rand) string {
+	size := f.max - f.min + 1
+	step := func() string { return "/" + strconv.Itoa(1+r.Intn(size)) }
+	switch r.Intn(6) {
+	case 0, 1:
+		return "*"
+	case 2:
+		return "*" + step()
+	case 3:
+		return f.randomValue(r, f.min+r.Intn(size))
+	case 4:
+		return f.randomValue(r, f.min+r.Intn(size)) + step()
+	default:
+		start := f.min + r.Intn(size)
+		end := f.min + (start-f.min+1+r.Intn(size-1))%size // not start
+		part := f.randomValue(r, start) + "-" + f.randomValue(r, end)
+		if r.Intn(2) == 0 {
+			part += step()
+		}
+		return part
+	}
+}
+
+// randomValue writes v as a number or, if the field has names, sometimes as
+// its name or the name's first three letters, in lower, upper, or title case.
+func (f exprField) randomValue(r *rand.Rand, v int) string {
+	if f.names == nil || r.Intn(2) == 0 {
+		return strconv.Itoa(v)
+	}
+	name := f.names[v-f.min]
+	if r.Intn(2) == 0 {
+		name = name[:3]
+	}
+	switch r.Intn(3) {
+	case 0:
+		name = strings.ToUpper(name)
+	case 1:
+		name = strings.ToUpper(name[:1]) + name[1:]
+	}
+	return name
+}
@@ -0,0 +1,46 @@
+package crontest
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cespare/cron"
+)
+
+func TestRandomExpr(t *testing.T) {
+	never, err := cron.Parse("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := rand.New(rand.NewSource(1))
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	seen := make(map[string]bool)
+	for i := 0; i < 2000; i++ {
+		expr := RandomExpr(r)
+		s, err := cron.Parse(expr)
+		if err != nil {
+			t.Fatalf("RandomExpr returned %q, which Parse rejects: %s", expr, err)
+		}
+		if s1, err := cron.Parse(s.String()); err != nil || s1 != s {
+			t.Errorf("%q is formatted as %q, which parses as %v (error %v)", expr, s, s1, err)
+		}
+		if s.Hash64() != never.Hash64() {
+			t1 := s.Next(start)
+			if t2 := s.Next(t1); !t2.After(t1) || !s.Matches(t1) || !s.Matches(t2) {
+				t.Errorf("%q: Next gives %s, then %s", expr, t1, t2)
+			}
+		}
+		for _, feature := range []string{"@", ",", "-", "/", "*/", "jan", "Mon", "SAT", "Sunday"} {
+			if strings.Contains(expr, feature) {
+				seen[feature] = true
+			}
+		}
+	}
+	for _, feature := range []string{"@", ",", "-", "/", "*/", "jan", "Mon", "SAT", "Sunday"} {
+		if !seen[feature] {
+			t.Errorf("no expression contains %q", feature)
+		}
+	}
+}
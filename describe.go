@@ -0,0 +1,176 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Describe returns an English description of when s fires, such as
+// "At 03:00 on Wednesday" or "Every 5 minutes".
+func Describe(s Schedule) string {
+	desc := describeTime(s)
+	if days := describeDays(s); days != "" {
+		desc += " " + days
+	}
+	return desc
+}
+
+// maxListedTimes is the largest number of times of day that describeTime
+// lists individually (as in "At 09:00 and 17:00").
+const maxListedTimes = 4
+
+func describeTime(s Schedule) string {
+	mins := s.fieldValues(0)
+	hrs := s.fieldValues(1)
+	allMins := len(mins) == minutes
+	allHours := len(hrs) == hours
+
+	var hourSuffix string
+	if !allHours {
+		hourSuffix = " during " + plural("hour", len(hrs)) + " " + describeValues(hrs, 1, "and")
+		if step, ok := wildcardStep(hrs, 1); ok {
+			hourSuffix = fmt.Sprintf(" of every %s hour", ordinal(step))
+		}
+	}
+	if allMins {
+		return "Every minute" + hourSuffix
+	}
+	if len(mins)*len(hrs) <= maxListedTimes {
+		var times []string
+		for _, h := range hrs {
+			for _, m := range mins {
+				times = append(times, fmt.Sprintf("%02d:%02d", h, m))
+			}
+		}
+		return "At " + joinList(times, "and")
+	}
+	if step, ok := wildcardStep(mins, 0); ok {
+		return fmt.Sprintf("Every %d minutes", step) + hourSuffix
+	}
+	minPart := fmt.Sprintf("%s %s", plural("minute", len(mins)), describeValues(mins, 0, "and"))
+	if allHours {
+		if len(mins) == 1 && mins[0] == 0 {
+			return "Every hour"
+		}
+		return fmt.Sprintf("At %s past every hour", minPart)
+	}
+	if step, ok := wildcardStep(hrs, 1); ok {
+		if len(mins) == 1 && mins[0] == 0 {
+			return fmt.Sprintf("Every %d hours", step)
+		}
+		return fmt.Sprintf("At %s past every %s hour", minPart, ordinal(step))
+	}
+	return fmt.Sprintf("At %s past %s %s", minPart, plural("hour", len(hrs)), describeValues(hrs, 1, "and"))
+}
+
+func describeDays(s Schedule) string {
+	var parts []string
+	domVals := s.fieldValues(2)
+	dowVals := s.fieldValues(4)
+	allDoms := len(domVals) == doms
+	allDows := len(dowVals) == dows
+	if !allDoms {
+		parts = append(parts, fmt.Sprintf("on %s %s of the month", plural("day", len(domVals)), describeValues(domVals, 2, "and")))
+		if !allDows {
+			parts = append(parts, "if it is a "+describeValues(dowVals, 4, "or"))
+		}
+	} else if !allDows {
+		parts = append(parts, "on "+describeValues(dowVals, 4, "and"))
+	}
+	if monthVals := s.fieldValues(3); len(monthVals) < months {
+		parts = append(parts, "in "+describeValues(monthVals, 3, "and"))
+	}
+	return strings.Join(parts, " ")
+}
+
+// wildcardStep reports whether vals is the set of values given by */n for
+// some n > 1 and, if so, returns n.
+func wildcardStep(vals []int, fieldIndex int) (int, bool) {
+	if len(vals) < 2 || vals[0] != fieldMin(fieldIndex) {
+		return 0, false
+	}
+	step := vals[1] - vals[0]
+	for i := 2; i < len(vals); i++ {
+		if vals[i]-vals[i-1] != step {
+			return 0, false
+		}
+	}
+	max := fieldMin(fieldIndex) + fieldSizes[fieldIndex] - 1
+	if vals[len(vals)-1]+step <= max {
+		return 0, false
+	}
+	return step, true
+}
+
+// describeValues describes a set of field values as an English list, writing
+// runs of three or more consecutive values as ranges.
+func describeValues(vals []int, fieldIndex int, conj string) string {
+	var items []string
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			items = append(items, describeValue(vals[i], fieldIndex)+" through "+describeValue(vals[j], fieldIndex))
+		} else {
+			for k := i; k <= j; k++ {
+				items = append(items, describeValue(vals[k], fieldIndex))
+			}
+		}
+		i = j + 1
+	}
+	return joinList(items, conj)
+}
+
+func describeValue(v, fieldIndex int) string {
+	switch fieldIndex {
+	case 3:
+		return capitalize(monthNames[v-1])
+	case 4:
+		return capitalize(dowNames[v])
+	}
+	return strconv.Itoa(v)
+}
+
+// joinList joins items as an English list using the conjunction conj
+// ("a", "a and b", "a, b, and c").
+func joinList(items []string, conj string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return items[0] + " " + conj + " " + items[1]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + ", " + conj + " " + items[len(items)-1]
+}
+
+func capitalize(s string) string {
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func plural(word string, n int) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 10 {
+	case 1:
+		suffix = "st"
+	case 2:
+		suffix = "nd"
+	case 3:
+		suffix = "rd"
+	}
+	if n%100 >= 11 && n%100 <= 13 {
+		suffix = "th"
+	}
+	return strconv.Itoa(n) + suffix
+}
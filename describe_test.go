@@ -0,0 +1,32 @@
+package cron
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "Every minute"},
+		{"*/5 * * * *", "Every 5 minutes"},
+		{"*/15 9-17 * * *", "Every 15 minutes during hours 9 through 17"},
+		{"* 3 * * *", "Every minute during hour 3"},
+		{"0 * * * *", "Every hour"},
+		{"15 * * * *", "At minute 15 past every hour"},
+		{"1,59 * * * *", "At minutes 1 and 59 past every hour"},
+		{"0 */6 * * *", "At 00:00, 06:00, 12:00, and 18:00"},
+		{"0 */4 * * *", "Every 4 hours"},
+		{"30 */2 * * *", "At minute 30 past every 2nd hour"},
+		{"0 3 * * Wed", "At 03:00 on Wednesday"},
+		{"0,30 9,17 * * MON-FRI", "At 09:00, 09:30, 17:00, and 17:30 on Monday through Friday"},
+		{"0 9-17 * * *", "At minute 0 past hours 9 through 17"},
+		{"0 0 1 */3 *", "At 00:00 on day 1 of the month in January, April, July, and October"},
+		{"0 12 13 * Fri", "At 12:00 on day 13 of the month if it is a Friday"},
+		{"0 0 1,15 * Sat,Sun", "At 00:00 on days 1 and 15 of the month if it is a Sunday or Saturday"},
+		{"0 0 * 12 *", "At 00:00 in December"},
+	} {
+		if got := Describe(mustParse(t, tt.expr)); got != tt.want {
+			t.Errorf("Describe(%q) = %q; want %q", tt.expr, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Dialect is a variant of the cron expression syntax.
+type Dialect int
+
+const (
+	// Standard is the syntax accepted by Parse.
+	Standard Dialect = iota
+	// Jenkins is the five-field syntax used by Jenkins. Days of the week
+	// are numbered 0 through 7 (both 0 and 7 are Sunday) and names are not
+	// used. The H symbol and all of the named schedules (which Jenkins
+	// defines in terms of H) pick values using a hash of the job name.
+	Jenkins
+	// Quartz is the syntax used by the Quartz scheduler. Expressions have
+	// six or seven fields: seconds, minutes, hours, day of month, month,
+	// day of week, and an optional year. Days of the week are numbered 1
+	// (Sunday) through 7 (Saturday), and one of the day of month and day of
+	// week fields must be ? ("no specific value").
+	Quartz
+	// AWS is the syntax of Amazon EventBridge schedule expressions. It is
+	// like Quartz, but without the seconds field and with a required year
+	// field.
+	AWS
+)
+
+var dialectNames = [...]string{
+	Standard: "standard",
+	Jenkins:  "jenkins",
+	Quartz:   "quartz",
+	AWS:      "aws",
+}
+
+func (d Dialect) String() string {
+	if d < 0 || int(d) >= len(dialectNames) {
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+	return dialectNames[d]
+}
+
+// A TranslateError is returned by Translate when an expression is valid but
+// cannot be represented in the target dialect.
+type TranslateError struct {
+	Expr     string
+	From, To Dialect
+	Reason   string
+}
+
+func (e *TranslateError) Error() string {
+	return fmt.Sprintf("cannot translate %q from %s to %s: %s", e.Expr, e.From, e.To, e.Reason)
+}
+
+// Translate converts the cron expression expr from one dialect to another.
+// The result is written in the canonical form described by Simplify (with
+// the changes needed to suit the target dialect).
+//
+// Where dialects differ in meaning, Translate preserves the times at which the
+// expression fires. It returns a *TranslateError if expr uses a construct
+// that has no equivalent in the target dialect, such as the H symbol (which
+// depends on the name of a Jenkins job), the L, W, and # symbols of Quartz
+// and AWS, or a schedule restricting both the day of month and the day of
+// week (which Quartz and AWS forbid). Non-zero Quartz seconds and a specific
+// Quartz or AWS year cannot be translated either.
+//
+// In the Jenkins, Quartz, and AWS dialects, a step interval after a single
+// value (as in 5/15) runs from that value to the end of the field.
+//
+// If from and to are the same, Translate checks that expr is valid in that
+// dialect and returns it unchanged.
+func Translate(expr string, from, to Dialect) (string, error) {
+	s, err := parseDialect(expr, from, to)
+	if from == to {
+		// Constructs without an equivalent elsewhere are fine when the
+		// dialect doesn't change.
+		var terr *TranslateError
+		if err != nil && !errors.As(err, &terr) {
+			return "", err
+		}
+		return expr, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return formatDialect(s, expr, from, to)
+}
+
+func parseDialect(expr string, from, to Dialect) (Schedule, error) {
+	unrepresentable := func(format string, args ...interface{}) error {
+		return &TranslateError{Expr: expr, From: from, To: to, Reason: fmt.Sprintf(format, args...)}
+	}
+	fields := strings.Fields(expr)
+	switch from {
+	case Standard:
+		return Parse(expr)
+	case Jenkins:
+		if strings.HasPrefix(expr, "@") {
+			return Schedule{}, unrepresentable("Jenkins defines %s using the H symbol", expr)
+		}
+		if len(fields) != 5 {
+			return Schedule{}, fmt.Errorf("wrong number of fields in Jenkins schedule %q (expected 5)", expr)
+		}
+	case Quartz:
+		if len(fields) != 6 && len(fields) != 7 {
+			return Schedule{}, fmt.Errorf("wrong number of fields in Quartz schedule %q (expected 6 or 7)", expr)
+		}
+		if sec, err := strconv.Atoi(fields[0]); err != nil || sec != 0 {
+			return Schedule{}, unrepresentable("seconds field %q is not 0", fields[0])
+		}
+		if len(fields) == 7 {
+			if year := fields[6]; year != "*" && year != "?" {
+				return Schedule{}, unrepresentable("year field %q is not *", year)
+			}
+		}
+		fields = fields[1:6]
+	case AWS:
+		if len(fields) != 6 {
+			return Schedule{}, fmt.Errorf("wrong number of fields in AWS schedule %q (expected 6)", expr)
+		}
+		if year := fields[5]; year != "*" {
+			return Schedule{}, unrepresentable("year field %q is not *", year)
+		}
+		fields = fields[:5]
+	default:
+		return Schedule{}, fmt.Errorf("unknown cron dialect %s", from)
+	}
+
+	if from == Quartz || from == AWS {
+		dom, dow := fields[2], fields[4]
+		if (dom == "?") == (dow == "?") {
+			return Schedule{}, fmt.Errorf("exactly one of the day of month and day of week fields of %s schedule %q must be ?", from, expr)
+		}
+		if strings.ContainsAny(strings.ToUpper(dom), "LW") {
+			return Schedule{}, unrepresentable("the L and W symbols have no equivalent")
+		}
+		if strings.Contains(dow, "#") || strings.Contains(strings.ToUpper(dow), "L") {
+			return Schedule{}, unrepresentable("the L and # symbols have no equivalent")
+		}
+	}
+
+	var s Schedule
+	for i, field := range fields {
+		if field == "?" && (from == Quartz || from == AWS) && (i == 2 || i == 4) {
+			field = "*"
+		}
+		for _, part := range strings.Split(field, ",") {
+			if from == Jenkins && (strings.EqualFold(part, "H") || strings.HasPrefix(strings.ToUpper(part), "H/") || strings.HasPrefix(strings.ToUpper(part), "H(")) {
+				return Schedule{}, unrepresentable("the H symbol depends on the Jenkins job name")
+			}
+			vals, err := expandDialectPart(part, i, from)
+			if err != nil {
+				return Schedule{}, err
+			}
+			for _, v := range vals {
+				s = s.set(fieldOffsets[i] + v - fieldMin(i))
+			}
+		}
+	}
+	return s, nil
+}
+
+// dialectRange gives the range of values of a field in a dialect.
+func dialectRange(fieldIndex int, d Dialect) (min, max int) {
+	if fieldIndex == 4 {
+		switch d {
+		case Jenkins:
+			return 0, 7
+		case Quartz, AWS:
+			return 1, 7
+		}
+	}
+	min = fieldMin(fieldIndex)
+	return min, min + fieldSizes[fieldIndex] - 1
+}
+
+// expandDialectPart returns the values given by one element of a list in
+// a field of an expression in dialect d. The values use the numbering of
+// Parse.
+func expandDialectPart(part string, fieldIndex int, d Dialect) ([]int, error) {
+	min, max := dialectRange(fieldIndex, d)
+	step := 1
+	incParts := strings.SplitN(part, "/", 2)
+	if len(incParts) > 1 {
+		var err error
+		step, err = strconv.Atoi(incParts[1])
+		if err != nil || step < 1 {
+			return nil, fmt.Errorf("invalid step increment: %q", incParts[1])
+		}
+	}
+	var start, end int
+	if incParts[0] == "*" {
+		start, end = min, max
+	} else {
+		rangeParts := strings.SplitN(incParts[0], "-", 2)
+		var err error
+		start, err = parseDialectValue(rangeParts[0], fieldIndex, d)
+		if err != nil {
+			return nil, err
+		}
+		end = start
+		if len(rangeParts) == 2 {
+			end, err = parseDialectValue(rangeParts[1], fieldIndex, d)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(incParts) > 1 {
+			end = max
+		}
+	}
+	var vals []int
+	for i, v := 0, start; ; i++ {
+		if i%step == 0 {
+			// Convert to the numbering of Parse.
+			switch {
+			case fieldIndex == 4 && d == Jenkins:
+				vals = append(vals, v%7)
+			case fieldIndex == 4 && (d == Quartz || d == AWS):
+				vals = append(vals, v-1)
+			default:
+				vals = append(vals, v)
+			}
+		}
+		if v == end {
+			break
+		}
+		v++
+		if v > max {
+			v = min
+		}
+	}
+	return vals, nil
+}
+
+func parseDialectValue(val string, fieldIndex int, d Dialect) (int, error) {
+	min, max := dialectRange(fieldIndex, d)
+	if n, err := strconv.Atoi(val); err == nil {
+		if n < min || n > max {
+			return 0, fmt.Errorf("invalid value %d for the %s field", n, fieldNames[fieldIndex])
+		}
+		return n, nil
+	}
+	switch fieldIndex {
+	case 3:
+		if n := matchUniquePrefix(val, monthNames); n >= 0 {
+			return n + 1, nil
+		}
+	case 4:
+		if n := matchUniquePrefix(val, dowNames); n >= 0 {
+			if d == Quartz || d == AWS {
+				n++
+			}
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid value %q for the %s field", val, fieldNames[fieldIndex])
+}
+
+func formatDialect(s Schedule, expr string, from, to Dialect) (string, error) {
+	var st formatStyle
+	switch to {
+	case Standard:
+		return s.String(), nil
+	case Jenkins:
+		st = formatStyle{numeric: true, noWrap: true}
+	case Quartz, AWS:
+		st = formatStyle{noWrap: true, startSteps: true}
+	default:
+		return "", fmt.Errorf("unknown cron dialect %s", to)
+	}
+	fields := make([]string, len(fieldSizes))
+	for i := range fields {
+		fields[i] = s.formatFieldStyle(i, st)
+	}
+	if to == Jenkins {
+		return strings.Join(fields, " "), nil
+	}
+
+	switch {
+	case fields[2] != "*" && fields[4] != "*":
+		return "", &TranslateError{
+			Expr:   expr,
+			From:   from,
+			To:     to,
+			Reason: "both the day of month and the day of week are restricted",
+		}
+	case fields[4] == "*":
+		fields[4] = "?"
+	default:
+		fields[2] = "?"
+	}
+	if to == Quartz {
+		return "0 " + strings.Join(fields, " "), nil
+	}
+	return strings.Join(fields, " ") + " *", nil
+}
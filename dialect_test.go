@@ -0,0 +1,95 @@
+package cron
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	for _, tt := range []struct {
+		expr     string
+		from, to Dialect
+		want     string
+	}{
+		{"*/15 * * * *", Standard, Quartz, "0 */15 * * * ?"},
+		{"0 3 * * Wed", Standard, Quartz, "0 0 3 ? * WED"},
+		{"0 3 * * Wed", Standard, AWS, "0 3 ? * WED *"},
+		{"0 3 * * Wed", Standard, Jenkins, "0 3 * * 3"},
+		{"0 0 1 */3 *", Standard, Quartz, "0 0 0 1 */3 ?"},
+		{"10,25,40,55 * * * *", Standard, Quartz, "0 10/15 * * * ?"},
+		{"10,25,40 * * * *", Standard, Quartz, "0 10,25,40 * * * ?"},
+		{"0 22-2 * * *", Standard, Quartz, "0 0 0-2,22,23 * * ?"},
+		{"0 22-2 * * Sat,Sun", Standard, Jenkins, "0 0-2,22,23 * * 0,6"},
+		{"0 0 0 ? * 2-6", Quartz, Standard, "0 0 * * MON-FRI"},
+		{"0 0 12 ? * SUN,SAT *", Quartz, Standard, "0 12 * * SAT,SUN"},
+		{"0 5/15 * * * ?", Quartz, Standard, "5-50/15 * * * *"},
+		{"0 0 L-2 * ?", Quartz, Standard, ""},
+		{"0 0 9 1 * ?", Quartz, AWS, "0 9 1 * ? *"},
+		{"0/30 8-17 ? * MON-FRI *", AWS, Standard, "0,30 8-17 * * MON-FRI"},
+		{"0 12 ? * 1 *", AWS, Jenkins, "0 12 * * 0"},
+		{"0 0 * * 5-7", Jenkins, Standard, "0 0 * * FRI-SUN"},
+		{"0 0 * * 7", Jenkins, Quartz, "0 0 0 ? * SUN"},
+		{"*/5 * * * 1-5", Jenkins, AWS, "*/5 * ? * MON-FRI *"},
+		{"H H * * *", Jenkins, Jenkins, "H H * * *"},
+		{"0 0 12 LW * ?", Quartz, Quartz, "0 0 12 LW * ?"},
+		{"0 3 * * wed", Standard, Standard, "0 3 * * wed"},
+	} {
+		got, err := Translate(tt.expr, tt.from, tt.to)
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("Translate(%q, %s, %s) = %q; want error", tt.expr, tt.from, tt.to, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Translate(%q, %s, %s): %s", tt.expr, tt.from, tt.to, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Translate(%q, %s, %s) = %q; want %q", tt.expr, tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTranslateFail(t *testing.T) {
+	for _, tt := range []struct {
+		expr     string
+		from, to Dialect
+		typed    bool   // whether the error is a *TranslateError
+		want     string // substring
+	}{
+		{"H H * * *", Jenkins, Standard, true, "H symbol"},
+		{"@daily", Jenkins, Standard, true, "H symbol"},
+		{"0 12 13 * Fri", Standard, Quartz, true, "both the day of month and the day of week"},
+		{"0 12 13 * Fri", Standard, AWS, true, "both the day of month and the day of week"},
+		{"30 0 12 * * ?", Quartz, Standard, true, "seconds"},
+		{"0 0 12 * * ? 2025", Quartz, Standard, true, "year"},
+		{"0 12 * * ? 2025", AWS, Standard, true, "year"},
+		{"0 0 12 LW * ?", Quartz, Standard, true, "L and W"},
+		{"0 0 12 ? * 6#3", Quartz, Standard, true, "L and #"},
+		{"0 0 12 ? * 6L", Quartz, Standard, true, "L and #"},
+		{"0 0 12 * * *", Quartz, Standard, false, "must be ?"},
+		{"0 0 12 * *", Quartz, Standard, false, "wrong number of fields"},
+		{"0 12 * * ?", AWS, Standard, false, "wrong number of fields"},
+		{"0 0 12 ? * 0", Quartz, Standard, false, "invalid value 0"},
+		{"0 0 * * 8", Jenkins, Standard, false, "invalid value 8"},
+		{"* * * * 7", Standard, Jenkins, false, "invalid value"},
+		{"0 3 * * XYZ", Standard, Standard, false, "XYZ"},
+		{"0 0 * * 8", Jenkins, Jenkins, false, "invalid value 8"},
+		{"0 0 12 * *", Quartz, Quartz, false, "wrong number of fields"},
+	} {
+		_, err := Translate(tt.expr, tt.from, tt.to)
+		if err == nil {
+			t.Errorf("Translate(%q, %s, %s) succeeded; want error", tt.expr, tt.from, tt.to)
+			continue
+		}
+		var te *TranslateError
+		if got := errors.As(err, &te); got != tt.typed {
+			t.Errorf("Translate(%q, %s, %s): got error %q (%T); want *TranslateError: %t", tt.expr, tt.from, tt.to, err, err, tt.typed)
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Translate(%q, %s, %s): got error %q; want substring %q", tt.expr, tt.from, tt.to, err, tt.want)
+		}
+	}
+}
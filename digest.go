@@ -0,0 +1,96 @@
+package cron
+
+import "hash/fnv"
+
+// Hash64 returns a 64-bit digest of the times at which s fires. Schedules that
+// fire at exactly the same times have the same digest, regardless of how they
+// were written: "0-59/1 * * * *" and "* * * * *" hash identically, as do
+// "0 0 * 2 *" and "0 0 1-29 2 *". All schedules that never fire (including
+// invalid schedules) share a single digest.
+//
+// The digest is stable across processes and versions of this package, so it
+// may be stored.
+func (s Schedule) Hash64() uint64 {
+	n := s.normalize()
+	h := fnv.New64a()
+	h.Write(n.b[:])
+	return h.Sum64()
+}
+
+// Equivalent reports whether the cron expressions expr1 and expr2 fire at
+// exactly the same times. Expressions are parsed with Parse. Two expressions
+// that never fire (such as "0 0 30 2 *") are considered equivalent.
+func Equivalent(expr1, expr2 string) (bool, error) {
+	s1, err := Parse(expr1)
+	if err != nil {
+		return false, err
+	}
+	s2, err := Parse(expr2)
+	if err != nil {
+		return false, err
+	}
+	return s1.normalize() == s2.normalize(), nil
+}
+
+// GroupEquivalent partitions schedules into groups of schedules which fire
+// at exactly the same times, as with Equivalent, however they were written.
+// Each group holds the indexes in schedules of its members, in increasing
+// order, and the groups are in the order of their first members. For
+// example, the schedules of
+//
+//	"0 0 * * *", "*/5 * * * *", "@daily", "0 0 1-31 * 0-6"
+//
+// are grouped as [[0 2 3] [1]]. As with Hash64, all schedules which never
+// fire (including invalid schedules) are grouped together.
+func GroupEquivalent(schedules []Schedule) [][]int {
+	var groups [][]int
+	index := make(map[Schedule]int) // normalized schedule -> index in groups
+	for i, s := range schedules {
+		n := s.normalize()
+		g, ok := index[n]
+		if !ok {
+			g = len(groups)
+			index[n] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+	return groups
+}
+
+// maxMonthDays gives the largest day of each month (in any year).
+var maxMonthDays = [...]int{31, 29, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// normalize returns the canonical Schedule which fires at the same times as s.
+// Days of the month that occur in none of the schedule's months are cleared,
+// as are months which contain none of the schedule's days. If s never fires,
+// normalize returns the zero Schedule.
+func (s Schedule) normalize() Schedule {
+	if !s.Valid() {
+		return Schedule{}
+	}
+	n := s
+	for j := 0; j < doms; j++ {
+		n = n.unset(domOffset + j)
+	}
+	for j := 0; j < months; j++ {
+		n = n.unset(monthOffset + j)
+	}
+	fires := false
+	for m := 0; m < months; m++ {
+		if !s.isSet(monthOffset + m) {
+			continue
+		}
+		for d := 0; d < maxMonthDays[m]; d++ {
+			if s.isSet(domOffset + d) {
+				n = n.set(monthOffset + m)
+				n = n.set(domOffset + d)
+				fires = true
+			}
+		}
+	}
+	if !fires {
+		return Schedule{}
+	}
+	return n
+}
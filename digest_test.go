@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHash64(t *testing.T) {
+	for _, tt := range []struct {
+		expr1, expr2 string
+		same         bool
+	}{
+		{"* * * * *", "0-59/1 * * * *", true},
+		{"*/15 * * * *", "0,15,30,45 * * * *", true},
+		{"0 0 * 2 *", "0 0 1-29 2 *", true},
+		{"0 0 31 * *", "0 0 31 1,3,5,7,8,10,12 *", true},
+		{"0 0 30 2 *", "0 0 31 4 *", true}, // both never fire
+		{"* * * * *", "0 * * * *", false},
+		{"0 0 * * *", "0 0 * * 0", false},
+		{"0 0 28 2 *", "0 0 29 2 *", false},
+	} {
+		h1 := mustParse(t, tt.expr1).Hash64()
+		h2 := mustParse(t, tt.expr2).Hash64()
+		if got := h1 == h2; got != tt.same {
+			t.Errorf("Hash64(%q) == Hash64(%q): got %t; want %t", tt.expr1, tt.expr2, got, tt.same)
+		}
+	}
+}
+
+func TestHash64Stable(t *testing.T) {
+	// The digest must not change between versions of the package.
+	const want = 0x6ac73c50803e1353
+	if got := mustParse(t, "0 3 * * Wed").Hash64(); got != want {
+		t.Errorf("Hash64 of \"0 3 * * Wed\" = %#x; want %#x", got, uint64(want))
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	for _, tt := range []struct {
+		expr1, expr2 string
+		want         bool
+	}{
+		{"* * * * *", "0-59/1 * * * *", true},
+		{"0 0 * * *", "@daily", true},
+		{"0 0 * * 1-5", "0 0 * * MON,TUE,WED,THU,FRI", true},
+		{"0 0 1-31 2 *", "0 0 * FEB *", true},
+		{"0 0 30 2 *", "0 0 31 2 *", true},
+		{"0 0 * * *", "0 0 * * 0", false},
+		{"0 * * * *", "30 * * * *", false},
+		{"0 0 30 1,2 *", "0 0 30 1 *", true},
+		{"0 0 30,31 1,2 *", "0 0 30 1 *", false},
+	} {
+		got, err := Equivalent(tt.expr1, tt.expr2)
+		if err != nil {
+			t.Errorf("Equivalent(%q, %q): %s", tt.expr1, tt.expr2, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Equivalent(%q, %q) = %t; want %t", tt.expr1, tt.expr2, got, tt.want)
+		}
+	}
+	if _, err := Equivalent("* * * * *", "* * *"); err == nil {
+		t.Error("Equivalent accepted an invalid expression")
+	}
+}
+
+func TestGroupEquivalent(t *testing.T) {
+	var schedules []Schedule
+	for _, expr := range []string{
+		"0 0 * * *",
+		"*/5 * * * *",
+		"@daily",
+		"0 0 30 2 *",
+		"0 0 1-31 * 0-6",
+		"0,5,10,15,20,25,30,35,40,45,50,55 * * * *",
+		"0 0 31 4 *",
+		"0 0 * * SUN",
+	} {
+		schedules = append(schedules, mustParse(t, expr))
+	}
+	schedules = append(schedules, Schedule{}) // invalid
+	got := GroupEquivalent(schedules)
+	want := [][]int{{0, 2, 4}, {1, 5}, {3, 6, 8}, {7}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GroupEquivalent: (-want, +got)\n%s", diff)
+	}
+	if got := GroupEquivalent(nil); got != nil {
+		t.Errorf("GroupEquivalent(nil) = %v; want nil", got)
+	}
+}
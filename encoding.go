@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+)
+
+// binaryVersion is the version byte that prefixes the binary encoding of a
+// Schedule. It must be incremented whenever the layout of the encoded bitset
+// changes.
+const binaryVersion = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler. The encoding is a single
+// version byte followed by the schedule's bitset.
+func (s Schedule) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 1+scheduleBytes)
+	b[0] = binaryVersion
+	copy(b[1:], s.b[:])
+	return b, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It decodes data
+// produced by MarshalBinary from this or any earlier version of the package.
+func (s *Schedule) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("cannot decode empty data as a cron schedule")
+	}
+	switch v := data[0]; v {
+	case 1:
+		if len(data) != 1+scheduleBytes {
+			return fmt.Errorf("invalid binary cron schedule length %d (expected %d)", len(data), 1+scheduleBytes)
+		}
+		var s1 Schedule
+		copy(s1.b[:], data[1:])
+		// Bits past the end of the last field are never set.
+		if s1.b[scheduleBytes-1]>>(scheduleBits%8) != 0 {
+			return errors.New("invalid binary cron schedule: unused bits are set")
+		}
+		// The zero Schedule round-trips, but no other invalid one does.
+		if s1 != (Schedule{}) {
+			if err := s1.Validate(); err != nil {
+				return fmt.Errorf("invalid binary cron schedule: %w", err)
+			}
+		}
+		*s = s1
+		return nil
+	default:
+		return fmt.Errorf("unknown binary cron schedule version %d", v)
+	}
+}
@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"0 0 1 1 0",
+		"1,3-5,10-45/10,58 * * * *",
+		"0 3 * * Wed",
+		"59 23 31 12 6",
+	} {
+		s, err := Parse(expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %s", expr, err)
+		}
+		b, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary(%q): %s", expr, err)
+		}
+		if len(b) != 1+scheduleBytes {
+			t.Errorf("MarshalBinary(%q): got %d bytes; want %d", expr, len(b), 1+scheduleBytes)
+		}
+		var s1 Schedule
+		if err := s1.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary(%q): %s", expr, err)
+		}
+		if s1 != s {
+			t.Errorf("round trip of %q: got %v; want %v", expr, s1, s)
+		}
+	}
+}
+
+func TestUnmarshalBinaryFail(t *testing.T) {
+	valid, err := mustParse(t, "* * * * *").MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	highBits := append([]byte(nil), valid...)
+	highBits[len(highBits)-1] |= 0x80
+	noMinutes := append([]byte(nil), valid...)
+	for i := 0; i < minutes; i++ {
+		noMinutes[1+i/8] &^= 1 << (i % 8)
+	}
+	for _, tt := range []struct {
+		data []byte
+		want string // substring
+	}{
+		{nil, "empty"},
+		{[]byte{0}, "unknown binary cron schedule version 0"},
+		{[]byte{2, 0, 0}, "unknown binary cron schedule version 2"},
+		{valid[:len(valid)-1], "invalid binary cron schedule length"},
+		{append(valid, 0), "invalid binary cron schedule length"},
+		{highBits, "unused bits"},
+		{noMinutes, "no values in the minute field"},
+	} {
+		var s Schedule
+		err := s.UnmarshalBinary(tt.data)
+		if err == nil {
+			t.Errorf("UnmarshalBinary(%v) succeeded; want error", tt.data)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("UnmarshalBinary(%v): got error %q; want substring %q", tt.data, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package cron
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A FileRunner is a Runner whose jobs are listed in a file in crontab format.
+// Rather than shell commands, the file names handlers, which are Jobs that
+// the program registers with NewFileRunner. For example, given handlers
+// named "rotate-logs" and "backup", the file could contain
+//
+//	# Rotate logs every hour and back up nightly.
+//	0 * * * *   rotate-logs
+//	30 2 * * *  backup
+//
+// Each line is a schedule, in the format accepted by Parse or "@reboot" (see
+// Reboot), followed by a handler name. Blank lines and lines beginning
+// with # are ignored. Each job is named after its handler (see WithName).
+//
+// The file may be changed while the program runs; see Reload, Watch, and
+// ReloadOnSignal.
+type FileRunner struct {
+	*Runner
+	path     string
+	handlers map[string]Job
+
+	mu     sync.Mutex // serializes reloads
+	jobs   map[crontabLine][]JobID
+	loaded os.FileInfo // the file as of the last successful load
+}
+
+// NewFileRunner returns a FileRunner, configured by the given options, with
+// the jobs listed in the file at path. The handlers map the handler names
+// used in the file to the Jobs to run.
+func NewFileRunner(path string, handlers map[string]Job, opts ...RunnerOption) (*FileRunner, error) {
+	fr := &FileRunner{
+		Runner:   NewRunner(opts...),
+		path:     path,
+		handlers: handlers,
+		jobs:     make(map[crontabLine][]JobID),
+	}
+	if err := fr.Reload(); err != nil {
+		return nil, err
+	}
+	return fr, nil
+}
+
+// Reload reads the file again and updates the jobs to match. Jobs whose
+// lines are unchanged are kept as they are, so they keep their state, such
+// as their running status and when they last ran. Jobs whose lines were
+// removed or changed are removed, as by Runner.Remove, and jobs for new or
+// changed lines are added. If the file cannot be read or is invalid (which
+// includes a schedule which never fires, such as "0 0 30 2 *"), Reload
+// returns an error and leaves the jobs unchanged.
+func (fr *FileRunner) Reload() error {
+	f, err := os.Open(fr.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	lines, err := readCrontabLines(f, fr.path, fr.handlers)
+	if err != nil {
+		return err
+	}
+
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	fr.loaded = fi
+	old := fr.jobs
+	fr.jobs = make(map[crontabLine][]JobID)
+	for _, line := range lines {
+		var id JobID
+		if ids := old[line]; len(ids) > 0 {
+			id = ids[0]
+			old[line] = ids[1:]
+		} else {
+			id = fr.Add(line.rec, fr.handlers[line.handler], WithName(line.handler))
+		}
+		fr.jobs[line] = append(fr.jobs[line], id)
+	}
+	for _, ids := range old {
+		for _, id := range ids {
+			fr.Remove(id)
+		}
+	}
+	return nil
+}
+
+// Watch checks the file every interval, until ctx is done, and reloads it
+// if it has changed since it was last loaded, judging by its size and
+// modification time. It passes errors to onError, if it is not nil. An
+// invalid file is reported only once, until it changes again.
+func (fr *FileRunner) Watch(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var failed os.FileInfo // the file as of the last failed load
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		fi, err := os.Stat(fr.path)
+		if err == nil {
+			fr.mu.Lock()
+			loaded := fr.loaded
+			fr.mu.Unlock()
+			if sameFile(fi, loaded) || sameFile(fi, failed) {
+				continue
+			}
+			if err = fr.Reload(); err != nil {
+				failed = fi
+			}
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// sameFile reports whether fi1 and fi2 have the same size and modification
+// time. It reports false if either is nil.
+func sameFile(fi1, fi2 os.FileInfo) bool {
+	return fi1 != nil && fi2 != nil && fi1.Size() == fi2.Size() && fi1.ModTime().Equal(fi2.ModTime())
+}
+
+// ReloadOnSignal reloads the file each time the process receives one of
+// the given signals, until ctx is done. It passes errors from Reload to
+// onError, if it is not nil. Crontab files are conventionally reloaded on
+// SIGHUP:
+//
+//	go fr.ReloadOnSignal(ctx, logError, syscall.SIGHUP)
+func (fr *FileRunner) ReloadOnSignal(ctx context.Context, onError func(error), sig ...os.Signal) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, sig...)
+	defer signal.Stop(c)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c:
+		}
+		if err := fr.Reload(); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// A crontabLine is a job listed in a FileRunner's file.
+type crontabLine struct {
+	rec     Recurrence // a Schedule or Reboot
+	handler string
+}
+
+// readCrontabLines reads the jobs listed in a FileRunner's file, which is
+// called name in errors.
+func readCrontabLines(r io.Reader, name string, handlers map[string]Job) ([]crontabLine, error) {
+	var lines []crontabLine
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line, ok, err := parseCrontabLine(scanner.Text())
+		if err == nil && ok && handlers[line.handler] == nil {
+			err = fmt.Errorf("no handler named %q", line.handler)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", name, n, err)
+		}
+		if ok {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %s", name, err)
+	}
+	return lines, nil
+}
+
+// parseCrontabLine parses a line of a FileRunner's file. It reports false
+// if the line is blank or a comment.
+func parseCrontabLine(text string) (crontabLine, bool, error) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+		return crontabLine{}, false, nil
+	}
+	n := 6 // five schedule fields and the handler
+	if strings.HasPrefix(fields[0], "@") {
+		n = 2
+	}
+	if len(fields) != n {
+		return crontabLine{}, false, fmt.Errorf("wrong number of fields (expected a schedule and a handler name): %q", text)
+	}
+	if fields[0] == "@reboot" {
+		return crontabLine{rec: Reboot{}, handler: fields[1]}, true, nil
+	}
+	expr := strings.Join(fields[:n-1], " ")
+	s, err := Parse(expr)
+	if err != nil {
+		return crontabLine{}, false, err
+	}
+	if s.normalize() == (Schedule{}) {
+		return crontabLine{}, false, fmt.Errorf("schedule %q never fires", expr)
+	}
+	return crontabLine{rec: s, handler: fields[n-1]}, true, nil
+}
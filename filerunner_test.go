@@ -0,0 +1,254 @@
+package cron
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// writeTempFile writes contents to a new file in a temporary directory and
+// returns its path and a function which removes the directory.
+func writeTempFile(t *testing.T, contents string) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "crontab")
+	if err := ioutil.WriteFile(path, []byte(contents), 0o644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestNewFileRunner(t *testing.T) {
+	nop := func(context.Context) error { return nil }
+	handlers := map[string]Job{"rotate-logs": nop, "backup": nop}
+	path, cleanup := writeTempFile(t, `# Rotate logs every hour and back up nightly.
+0 * * * *   rotate-logs
+
+	30 2 * * *  backup
+@weekly backup
+@reboot rotate-logs
+`)
+	defer cleanup()
+	r, err := NewFileRunner(path, handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type job struct {
+		Name     string
+		Schedule string
+	}
+	var got []job
+	for _, info := range r.Entries() {
+		got = append(got, job{info.Name, info.Recurrence.(fmt.Stringer).String()})
+	}
+	want := []job{
+		{"rotate-logs", "0 * * * *"},
+		{"backup", "30 2 * * *"},
+		{"backup", "0 0 * * SUN"},
+		{"rotate-logs", "@reboot"},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("jobs (-got, +want):\n%s", diff)
+	}
+
+	for _, tt := range []struct {
+		contents string
+		err      string
+	}{
+		{"0 * * * * rotate-logs\n0 0 * * * vacuum\n", `:2: no handler named "vacuum"`},
+		{"0 * * * *\n", ":1: wrong number of fields"},
+		{"@daily backup extra\n", ":1: wrong number of fields"},
+		{"0 * * * * * backup\n", ":1: wrong number of fields"},
+		{"# ok\n61 * * * * backup\n", ":2: "},
+		{"@sometimes backup\n", ":1: unrecognized cron schedule name"},
+		{"0 0 30 2 * backup\n", `:1: schedule "0 0 30 2 *" never fires`},
+	} {
+		path, cleanup := writeTempFile(t, tt.contents)
+		_, err := NewFileRunner(path, handlers)
+		cleanup()
+		if err == nil || !strings.HasPrefix(err.Error(), path+tt.err) {
+			t.Errorf("NewFileRunner with %q: got error %v; want prefix %q", tt.contents, err, path+tt.err)
+		}
+	}
+	if _, err := NewFileRunner(filepath.Join(os.TempDir(), "no-such-crontab"), handlers); err == nil {
+		t.Error("NewFileRunner with missing file succeeded")
+	}
+}
+
+func TestFileRunnerRuns(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ran := make(chan string, 10)
+	handlers := map[string]Job{
+		"hello": func(context.Context) error {
+			ran <- "hello"
+			return nil
+		},
+	}
+	path, cleanup := writeTempFile(t, "@hourly hello\n")
+	defer cleanup()
+	r, err := NewFileRunner(path, handlers, WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Start()
+	defer r.Stop()
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(time.Hour)
+	select {
+	case name := <-ran:
+		if name != "hello" {
+			t.Errorf("ran %q; want hello", name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("handler did not run")
+	}
+}
+
+func TestFileRunnerReload(t *testing.T) {
+	nop := func(context.Context) error { return nil }
+	handlers := map[string]Job{"a": nop, "b": nop, "c": nop}
+	path, cleanup := writeTempFile(t, "0 * * * * a\n0 0 * * * b\n0 0 * * * c\n0 0 * * * c\n")
+	defer cleanup()
+	r, err := NewFileRunner(path, handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := func() map[string][]JobID {
+		m := make(map[string][]JobID)
+		for _, info := range r.Entries() {
+			key := info.Recurrence.(Schedule).String() + " " + info.Name
+			m[key] = append(m[key], info.ID)
+		}
+		return m
+	}
+	before := ids()
+
+	// a is unchanged, b's schedule changes, one copy of c is removed, and
+	// d is new.
+	handlers["d"] = nop
+	if err := ioutil.WriteFile(path, []byte("0 * * * * a\n# comment\n0 12 * * * b\n0 0 * * * c\n@daily d\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	after := ids()
+	if diff := cmp.Diff(after["0 * * * * a"], before["0 * * * * a"]); diff != "" {
+		t.Errorf("unchanged job a has a different ID (-got, +want):\n%s", diff)
+	}
+	if len(after["0 0 * * * c"]) != 1 || after["0 0 * * * c"][0] != before["0 0 * * * c"][0] {
+		t.Errorf("after removing a copy of c, jobs for c are %v; want [%d]", after["0 0 * * * c"], before["0 0 * * * c"][0])
+	}
+	if _, ok := after["0 0 * * * b"]; ok {
+		t.Error("old b job was not removed")
+	}
+	if len(after["0 12 * * * b"]) != 1 || len(after["0 0 * * * d"]) != 1 {
+		t.Errorf("new jobs missing: %v", after)
+	}
+	if len(r.Entries()) != 4 {
+		t.Errorf("after Reload, %d jobs; want 4", len(r.Entries()))
+	}
+
+	// An invalid file leaves the jobs alone.
+	for _, contents := range []string{"0 * * * * nonexistent\n", "0 0 30 2 * a\n"} {
+		if err := ioutil.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Reload(); err == nil {
+			t.Errorf("Reload of invalid file %q succeeded", contents)
+		}
+		if diff := cmp.Diff(ids(), after); diff != "" {
+			t.Errorf("after failed Reload of %q, jobs changed (-got, +want):\n%s", contents, diff)
+		}
+	}
+}
+
+func TestFileRunnerWatch(t *testing.T) {
+	nop := func(context.Context) error { return nil }
+	handlers := map[string]Job{"a": nop, "b": nop}
+	path, cleanup := writeTempFile(t, "0 * * * * a\n")
+	defer cleanup()
+	r, err := NewFileRunner(path, handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 10)
+	done := make(chan struct{})
+	go func() {
+		r.Watch(ctx, 5*time.Millisecond, func(err error) { errs <- err })
+		close(done)
+	}()
+	if err := ioutil.WriteFile(path, []byte("0 * * * * a\n0 * * * * b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "reload", func() bool { return len(r.Entries()) == 2 })
+	if err := ioutil.WriteFile(path, []byte("bad\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "wrong number of fields") {
+			t.Errorf("Watch reported %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not report the invalid file")
+	}
+	cancel()
+	<-done
+}
+
+func TestFileRunnerReloadOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("cannot send SIGHUP on Windows")
+	}
+	nop := func(context.Context) error { return nil }
+	handlers := map[string]Job{"a": nop, "b": nop}
+	path, cleanup := writeTempFile(t, "0 * * * * a\n")
+	defer cleanup()
+	r, err := NewFileRunner(path, handlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keep the signal from killing the test if it arrives after
+	// ReloadOnSignal returns.
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	defer signal.Stop(c)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		r.ReloadOnSignal(ctx, func(err error) { t.Error(err) }, syscall.SIGHUP)
+		close(done)
+	}()
+	if err := ioutil.WriteFile(path, []byte("0 * * * * a\n0 * * * * b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "reload", func() bool {
+		p, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Signal(syscall.SIGHUP)
+		time.Sleep(5 * time.Millisecond)
+		return len(r.Entries()) == 2
+	})
+	cancel()
+	<-done
+}
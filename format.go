@@ -0,0 +1,174 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Simplify parses expr and returns a simpler equivalent expression in a
+// canonical form: value lists are collapsed into ranges and step intervals
+// where possible, months and days of the week are written as abbreviated
+// names, and expressions that match one of the named schedules (such as
+// "@daily") are replaced by that name.
+//
+// For example, "0,15,30,45 0 * * 1-5" simplifies to "*/15 0 * * MON-FRI".
+func Simplify(expr string) (string, error) {
+	s, err := Parse(expr)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range []string{"@hourly", "@daily", "@weekly", "@monthly"} {
+		if s1, _ := Parse(name); s1 == s {
+			return name, nil
+		}
+	}
+	return s.String(), nil
+}
+
+// String returns the canonical cron expression for s, as described by
+// Simplify (except that named schedules are not used). Parsing the result
+// gives back a Schedule equal to s.
+func (s Schedule) String() string {
+	fields := make([]string, len(fieldSizes))
+	for i := range fieldSizes {
+		fields[i] = s.formatField(i)
+	}
+	return strings.Join(fields, " ")
+}
+
+// fieldValues returns the values set in the given field, in increasing order,
+// using the numbering of cron expressions (that is, days of the month and
+// months start at 1).
+func (s Schedule) fieldValues(fieldIndex int) []int {
+	var vals []int
+	for j := 0; j < fieldSizes[fieldIndex]; j++ {
+		if s.isSet(fieldOffsets[fieldIndex] + j) {
+			vals = append(vals, j+fieldMin(fieldIndex))
+		}
+	}
+	return vals
+}
+
+// fieldMin is the smallest value of a field in a cron expression.
+func fieldMin(fieldIndex int) int {
+	switch fieldIndex {
+	case 2, 3:
+		return 1
+	}
+	return 0
+}
+
+func (s Schedule) formatField(fieldIndex int) string {
+	return s.formatFieldStyle(fieldIndex, formatStyle{})
+}
+
+// A formatStyle selects the syntax used to format a field. The zero
+// formatStyle gives the canonical syntax described by Simplify.
+type formatStyle struct {
+	// numeric disables month and weekday names.
+	numeric bool
+	// noWrap disables ranges that wrap around the end of the field (such
+	// as 22-2 for hours).
+	noWrap bool
+	// startSteps writes step intervals in the start/step form used by
+	// Quartz, which runs from start to the end of the field. Steps which
+	// don't run to the end of the field and steps in the day of week
+	// field (which is numbered differently) are not used.
+	startSteps bool
+}
+
+func (s Schedule) formatFieldStyle(fieldIndex int, st formatStyle) string {
+	vals := s.fieldValues(fieldIndex)
+	if len(vals) == fieldSizes[fieldIndex] {
+		return "*"
+	}
+	best := formatList(vals, fieldIndex, st)
+	if alt, ok := formatStep(vals, fieldIndex, st); ok && len(alt) <= len(best) {
+		best = alt
+	}
+	return best
+}
+
+// formatList formats vals as a list of single values and ranges. Unless st
+// disables it, a range that ends at the field's maximum value is joined with
+// one that starts at its minimum value into a single wrapping range (such as
+// 22-2 for hours).
+func formatList(vals []int, fieldIndex int, st formatStyle) string {
+	type run struct{ start, end int }
+	var runs []run
+	for i := 0; i < len(vals); {
+		j := i
+		for j+1 < len(vals) && vals[j+1] == vals[j]+1 {
+			j++
+		}
+		runs = append(runs, run{vals[i], vals[j]})
+		i = j + 1
+	}
+	min := fieldMin(fieldIndex)
+	max := min + fieldSizes[fieldIndex] - 1
+	if !st.noWrap && len(runs) > 1 && runs[0].start == min && runs[len(runs)-1].end == max {
+		last := runs[len(runs)-1]
+		runs[0].start = last.start
+		runs = runs[:len(runs)-1]
+		runs = append(runs[1:], runs[0])
+	}
+	var parts []string
+	for _, r := range runs {
+		n := (r.end-r.start+fieldSizes[fieldIndex])%fieldSizes[fieldIndex] + 1
+		switch n {
+		case 1:
+			parts = append(parts, formatValue(r.start, fieldIndex, st))
+		case 2:
+			parts = append(parts, formatValue(r.start, fieldIndex, st), formatValue(r.end, fieldIndex, st))
+		default:
+			parts = append(parts, formatValue(r.start, fieldIndex, st)+"-"+formatValue(r.end, fieldIndex, st))
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+// formatStep formats vals using a step interval, if vals is an arithmetic
+// progression of at least three values.
+func formatStep(vals []int, fieldIndex int, st formatStyle) (string, bool) {
+	if len(vals) < 3 {
+		return "", false
+	}
+	step := vals[1] - vals[0]
+	for i := 2; i < len(vals); i++ {
+		if vals[i]-vals[i-1] != step {
+			return "", false
+		}
+	}
+	min := fieldMin(fieldIndex)
+	max := min + fieldSizes[fieldIndex] - 1
+	suffix := "/" + strconv.Itoa(step)
+	first, last := vals[0], vals[len(vals)-1]
+	toEnd := last+step > max
+	if st.startSteps {
+		if !toEnd || fieldIndex == 4 {
+			return "", false
+		}
+		if first == min {
+			return "*" + suffix, true
+		}
+		return formatValue(first, fieldIndex, st) + suffix, true
+	}
+	if first == min && toEnd {
+		return "*" + suffix, true
+	}
+	return formatValue(first, fieldIndex, st) + "-" + formatValue(last, fieldIndex, st) + suffix, true
+}
+
+// formatValue formats a single field value, using abbreviated names for
+// months and days of the week unless st disables them.
+func formatValue(v, fieldIndex int, st formatStyle) string {
+	if !st.numeric {
+		switch fieldIndex {
+		case 3:
+			return strings.ToUpper(monthNames[v-1][:3])
+		case 4:
+			return strings.ToUpper(dowNames[v][:3])
+		}
+	}
+	return strconv.Itoa(v)
+}
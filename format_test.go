@@ -0,0 +1,62 @@
+package cron
+
+import "testing"
+
+func TestString(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "* * * * *"},
+		{"0-59 0-23 1-31 1-12 0-6", "* * * * *"},
+		{"0 0 1 1 0", "0 0 1 JAN SUN"},
+		{"0 0 1 1 0-6", "0 0 1 JAN *"},
+		{"1,3-5,10-45/10,58 * * * *", "1,3-5,10,20,30,40,58 * * * *"},
+		{"0,15,30,45 * * * *", "*/15 * * * *"},
+		{"10,25,40,55 * * * *", "10-55/15 * * * *"},
+		{"* 0,6,12,18 */2 */3 *", "* */6 */2 */3 *"},
+		{"5,6 * * * *", "5,6 * * * *"},
+		{"* 21-3 * * *", "* 21-3 * * *"},
+		{"* 0-3,20-23 * * 0,6", "* 20-3 * * SAT,SUN"},
+		{"* * * APR-JUL MON,WED", "* * * APR-JUL MON,WED"},
+		{"* * * * 1-5", "* * * * MON-FRI"},
+		{"@monthly", "0 0 1 * *"},
+		{"@weekly", "0 0 * * SUN"},
+	} {
+		s := mustParse(t, tt.expr)
+		if got := s.String(); got != tt.want {
+			t.Errorf("Parse(%q).String() = %q; want %q", tt.expr, got, tt.want)
+		}
+		if s1 := mustParse(t, s.String()); s1 != s {
+			t.Errorf("Parse(%q).String() = %q does not parse to the same schedule", tt.expr, s.String())
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want string
+	}{
+		{"0,15,30,45 0 * * 1-5", "*/15 0 * * MON-FRI"},
+		{"0 0 * * *", "@daily"},
+		{"0 0 1-31 * *", "@daily"},
+		{"0 0 1 * *", "@monthly"},
+		{"0 0 * * sunday", "@weekly"},
+		{"0 * * * *", "@hourly"},
+		{"@daily", "@daily"},
+		{"0 3 * * 3", "0 3 * * WED"},
+	} {
+		got, err := Simplify(tt.expr)
+		if err != nil {
+			t.Errorf("Simplify(%q): %s", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Simplify(%q) = %q; want %q", tt.expr, got, tt.want)
+		}
+	}
+	if _, err := Simplify("* * *"); err == nil {
+		t.Error("Simplify accepted an invalid expression")
+	}
+}
@@ -0,0 +1,38 @@
+package cron
+
+import "time"
+
+// A Histogram holds the number of times a schedule fires in a window of
+// time, broken down by the hour of the day and the day of the week. It is
+// plain data, meant for charts, and it encodes naturally as JSON.
+type Histogram struct {
+	// Total is the number of times the schedule fires in the window.
+	Total int
+	// ByHour holds the number of times the schedule fires in each hour
+	// of the day, from 0 (midnight to 1am) to 23.
+	ByHour [24]int
+	// ByWeekday holds the number of times the schedule fires on each day
+	// of the week, indexed by time.Weekday (starting with Sunday).
+	ByWeekday [7]int
+}
+
+// Histogram returns the histogram of the times at which s fires from from
+// until to, as given by EachBetween. The hours and days of the week are
+// those of the times in from's location. Histogram panics if s is not
+// valid.
+func (s Schedule) Histogram(from, to time.Time) Histogram {
+	if err := s.Validate(); err != nil {
+		panic("Histogram() called on invalid schedule: " + err.Error())
+	}
+	var h Histogram
+	if s.normalize() == (Schedule{}) {
+		return h // never fires
+	}
+	s.EachBetween(from, to, func(t time.Time) bool {
+		h.Total++
+		h.ByHour[t.Hour()]++
+		h.ByWeekday[t.Weekday()]++
+		return true
+	})
+	return h
+}
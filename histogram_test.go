@@ -0,0 +1,49 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHistogram(t *testing.T) {
+	// 2026-03-02 is a Monday.
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 14)
+	for _, tt := range []struct {
+		expr string
+		want Histogram
+	}{
+		{
+			"0 9,17 * * MON-FRI",
+			Histogram{
+				Total:     20,
+				ByHour:    [24]int{9: 10, 17: 10},
+				ByWeekday: [7]int{0, 4, 4, 4, 4, 4, 0},
+			},
+		},
+		{
+			"*/30 12 * * SUN",
+			Histogram{
+				Total:     4,
+				ByHour:    [24]int{12: 4},
+				ByWeekday: [7]int{4, 0, 0, 0, 0, 0, 0},
+			},
+		},
+		{"0 0 30 2 *", Histogram{}},
+	} {
+		got := mustParse(t, tt.expr).Histogram(from, to)
+		if diff := cmp.Diff(tt.want, got); diff != "" {
+			t.Errorf("Histogram for %q: (-want, +got)\n%s", tt.expr, diff)
+		}
+	}
+
+	// Hours and weekdays are those of from's location.
+	loc := time.FixedZone("UTC-10", -10*60*60)
+	got := mustParse(t, "0 9 * * MON").Histogram(from.In(loc), to)
+	want := Histogram{Total: 2, ByHour: [24]int{9: 2}, ByWeekday: [7]int{1: 2}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Histogram in %s: (-want, +got)\n%s", loc, diff)
+	}
+}
@@ -0,0 +1,148 @@
+package cron
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// ICalOptions configures the iCalendar event produced by ICalEvent.
+type ICalOptions struct {
+	// UID is the event's unique identifier. If empty, one is derived from
+	// the schedule's digest (see Schedule.Hash64) and Summary, so events
+	// for the same schedule need different summaries (or explicit UIDs)
+	// to be told apart.
+	UID string
+	// Summary is the event's title.
+	Summary string
+	// Start is the beginning of the time range covered by the event: the
+	// event starts at the schedule's first occurrence at or after Start.
+	// The schedule is evaluated in Start's location.
+	Start time.Time
+	// End bounds the occurrences listed individually when the schedule
+	// cannot be written as a recurrence rule (see ICalEvent). If End is
+	// zero, occurrences during the 30 days following Start are listed.
+	End time.Time
+	// Duration is the length of each occurrence. If zero, each occurrence
+	// lasts for one minute.
+	Duration time.Duration
+	// Stamp is the event's DTSTAMP, the time at which it was created. If
+	// zero, the current time is used.
+	Stamp time.Time
+}
+
+// ICalEvent renders s as an iCalendar (RFC 5545) VEVENT component. The
+// result uses CRLF line endings and should be wrapped in a VCALENDAR object
+// to be served to calendar applications. ICalEvent returns an error if s
+// has no occurrence at or after opts.Start (that is, if s never fires).
+//
+// All times are written in UTC, so the event needs no VTIMEZONE component.
+// Schedules evaluated in UTC which fire at a single time of day are written
+// with an RRULE and have no end. Many calendar applications do not support
+// rules that fire several times a day, and in other locations the UTC times
+// of the occurrences shift when daylight saving time begins or ends, so
+// other schedules are instead written as a list of RDATEs covering the
+// occurrences between opts.Start and opts.End.
+func ICalEvent(s Schedule, opts ICalOptions) (string, error) {
+	if !s.Valid() {
+		panic("ICalEvent called on invalid schedule")
+	}
+	first := s.Next(opts.Start.Add(-time.Nanosecond))
+	if first.IsZero() {
+		return "", fmt.Errorf("schedule %q never fires", s)
+	}
+	d := opts.Duration
+	if d == 0 {
+		d = time.Minute
+	}
+	uid := opts.UID
+	if uid == "" {
+		uid = icalUID(s, opts.Summary)
+	}
+	stamp := opts.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	var b strings.Builder
+	writeLine := func(line string) {
+		b.WriteString(foldICalLine(line))
+	}
+	writeLine("BEGIN:VEVENT")
+	writeLine("UID:" + escapeICalText(uid))
+	writeLine("DTSTAMP:" + icalTime(stamp))
+	if opts.Summary != "" {
+		writeLine("SUMMARY:" + escapeICalText(opts.Summary))
+	}
+	writeLine("DTSTART:" + icalTime(first))
+	writeLine(fmt.Sprintf("DURATION:PT%dM", int64((d+time.Minute-1)/time.Minute)))
+	if opts.Start.Location() == time.UTC && s.firesOncePerDay() {
+		writeLine("RRULE:" + s.rrule())
+	} else {
+		end := opts.End
+		if end.IsZero() {
+			end = opts.Start.AddDate(0, 0, 30)
+		}
+		var dates []string
+		for t := s.Next(first); !t.IsZero() && t.Before(end); t = s.Next(t) {
+			dates = append(dates, icalTime(t))
+		}
+		if len(dates) > 0 {
+			writeLine("RDATE:" + strings.Join(dates, ","))
+		}
+	}
+	writeLine("END:VEVENT")
+	return b.String(), nil
+}
+
+// icalUID derives an event UID from the times at which s fires and the
+// event's summary.
+func icalUID(s Schedule, summary string) string {
+	n := s.normalize()
+	h := fnv.New64a()
+	h.Write(n.b[:])
+	h.Write([]byte(summary))
+	return fmt.Sprintf("%016x@github.com/cespare/cron", h.Sum64())
+}
+
+// icalTime formats t as an iCalendar DATE-TIME value in UTC.
+func icalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// firesOncePerDay reports whether s fires at a single time of day.
+func (s Schedule) firesOncePerDay() bool {
+	mins, _ := s.Minutes()
+	hrs, _ := s.Hours()
+	return len(mins) == 1 && len(hrs) == 1
+}
+
+var icalTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`;`, `\;`,
+	`,`, `\,`,
+	"\n", `\n`,
+)
+
+func escapeICalText(s string) string {
+	return icalTextEscaper.Replace(s)
+}
+
+// foldICalLine returns line terminated by CRLF and folded so that no line
+// is longer than 75 octets, as required by RFC 5545.
+func foldICalLine(line string) string {
+	var b strings.Builder
+	// Continuation lines begin with a space, leaving room for 74 octets.
+	for n := 75; len(line) > n; n = 74 {
+		for n > 0 && line[n]&0xc0 == 0x80 {
+			n--
+		}
+		b.WriteString(line[:n])
+		b.WriteString("\r\n ")
+		line = line[n:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
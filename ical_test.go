@@ -0,0 +1,130 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestICalEvent(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stamp := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		expr string
+		opts ICalOptions
+		want []string
+	}{
+		{
+			expr: "0 3 * * Wed",
+			opts: ICalOptions{
+				UID:     "backup",
+				Summary: "Nightly backup, part 1",
+				Start:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				Stamp:   stamp,
+			},
+			want: []string{
+				"BEGIN:VEVENT",
+				"UID:backup",
+				"DTSTAMP:20200101T000000Z",
+				`SUMMARY:Nightly backup\, part 1`,
+				"DTSTART:20200101T030000Z",
+				"DURATION:PT1M",
+				"RRULE:FREQ=WEEKLY;BYDAY=WE;BYHOUR=3;BYMINUTE=0",
+				"END:VEVENT",
+			},
+		},
+		{
+			expr: "30 9 1,15 * *",
+			opts: ICalOptions{
+				UID:      "report",
+				Start:    time.Date(2020, 3, 1, 0, 0, 0, 0, ny),
+				End:      time.Date(2020, 4, 2, 0, 0, 0, 0, ny),
+				Duration: time.Hour,
+				Stamp:    stamp,
+			},
+			// Daylight saving time begins on March 8.
+			want: []string{
+				"BEGIN:VEVENT",
+				"UID:report",
+				"DTSTAMP:20200101T000000Z",
+				"DTSTART:20200301T143000Z",
+				"DURATION:PT60M",
+				"RDATE:20200315T133000Z,20200401T133000Z",
+				"END:VEVENT",
+			},
+		},
+		{
+			expr: "0 9,17 * * *",
+			opts: ICalOptions{
+				UID:   "twice",
+				Start: time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC),
+				End:   time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC),
+				Stamp: stamp,
+			},
+			want: []string{
+				"BEGIN:VEVENT",
+				"UID:twice",
+				"DTSTAMP:20200101T000000Z",
+				"DTSTART:20200101T170000Z",
+				"DURATION:PT1M",
+				"RDATE:20200102T090000Z,20200102T170000Z",
+				"END:VEVENT",
+			},
+		},
+	} {
+		got, err := ICalEvent(mustParse(t, tt.expr), tt.opts)
+		if err != nil {
+			t.Errorf("ICalEvent(%q): %s", tt.expr, err)
+			continue
+		}
+		want := strings.Join(tt.want, "\r\n") + "\r\n"
+		if got != want {
+			t.Errorf("ICalEvent(%q):\ngot:\n%s\nwant:\n%s", tt.expr, got, want)
+		}
+	}
+}
+
+func TestICalEventNeverFires(t *testing.T) {
+	opts := ICalOptions{Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if _, err := ICalEvent(mustParse(t, "0 0 30 2 *"), opts); err == nil {
+		t.Error("ICalEvent succeeded for a schedule that never fires; want error")
+	}
+}
+
+func TestICalEventUID(t *testing.T) {
+	s := mustParse(t, "0 3 * * *")
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	uid := func(summary string) string {
+		event, err := ICalEvent(s, ICalOptions{Summary: summary, Start: start, Stamp: start})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.SplitN(event, "\r\n", 3)[1]
+	}
+	if uid("backup") == uid("cleanup") {
+		t.Errorf("events with different summaries share %s", uid("backup"))
+	}
+	if uid("backup") != uid("backup") {
+		t.Error("UID for the same schedule and summary is not stable")
+	}
+}
+
+func TestFoldICalLine(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 100)
+	folded := foldICalLine(line)
+	if !strings.HasSuffix(folded, "\r\n") {
+		t.Fatalf("folded line %q lacks a CRLF terminator", folded)
+	}
+	lines := strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n ")
+	for i, l := range lines {
+		if len(l) > 75 || (i > 0 && len(l) > 74) {
+			t.Errorf("line %d is too long (%d octets)", i, len(l))
+		}
+	}
+	if got := strings.Join(lines, ""); got != line {
+		t.Errorf("unfolding gave %q; want %q", got, line)
+	}
+}
@@ -0,0 +1,275 @@
+package cron
+
+import (
+	"container/heap"
+	"fmt"
+	mathbits "math/bits"
+	"time"
+)
+
+// A SchedulerIndex tracks the next occurrence of many Recurrences, identified
+// by string IDs, and reports which of them fires next. Adding, removing, and
+// popping each take O(log n) time for n Recurrences.
+//
+// A SchedulerIndex has a current time, which starts at the time given to
+// NewSchedulerIndex and advances to each occurrence returned by Pop.
+//
+// A SchedulerIndex is not safe for concurrent use.
+type SchedulerIndex struct {
+	now   time.Time
+	h     indexHeap
+	items map[string]*indexItem
+	seq   uint64
+}
+
+type indexItem struct {
+	id   string
+	r    Recurrence
+	next time.Time
+	seq  uint64 // order of addition, for breaking ties
+	pos  int    // index in the heap
+}
+
+// NewSchedulerIndex returns an empty SchedulerIndex whose current time is now.
+func NewSchedulerIndex(now time.Time) *SchedulerIndex {
+	return &SchedulerIndex{now: now, items: make(map[string]*indexItem)}
+}
+
+// Len returns the number of Recurrences in x.
+func (x *SchedulerIndex) Len() int {
+	return len(x.h)
+}
+
+// Add adds r to x under the given ID, replacing any Recurrence already
+// added with that ID. The first occurrence of r considered is the first
+// after x's current time. If r has no such occurrence, Add does nothing.
+func (x *SchedulerIndex) Add(id string, r Recurrence) {
+	x.Remove(id)
+	next := r.Next(x.now)
+	if next.IsZero() {
+		return
+	}
+	item := &indexItem{id: id, r: r, next: next, seq: x.seq}
+	x.seq++
+	x.items[id] = item
+	heap.Push(&x.h, item)
+}
+
+// Remove removes the Recurrence with the given ID from x. It reports whether
+// there was one.
+func (x *SchedulerIndex) Remove(id string) bool {
+	item, ok := x.items[id]
+	if !ok {
+		return false
+	}
+	heap.Remove(&x.h, item.pos)
+	delete(x.items, id)
+	return true
+}
+
+// Peek returns the ID and time of the next occurrence of any Recurrence in
+// x without removing it. If several Recurrences fire at the same time, the
+// one added first is returned. Peek reports false if x is empty.
+func (x *SchedulerIndex) Peek() (id string, t time.Time, ok bool) {
+	if len(x.h) == 0 {
+		return "", time.Time{}, false
+	}
+	return x.h[0].id, x.h[0].next, true
+}
+
+// Pop is like Peek, but it also advances x's current time to the returned
+// occurrence and moves the Recurrence on to its following occurrence. A
+// Recurrence with no more occurrences is removed from x.
+func (x *SchedulerIndex) Pop() (id string, t time.Time, ok bool) {
+	if len(x.h) == 0 {
+		return "", time.Time{}, false
+	}
+	item := x.h[0]
+	id, t = item.id, item.next
+	x.now = t
+	item.next = item.r.Next(t)
+	if item.next.IsZero() {
+		heap.Pop(&x.h)
+		delete(x.items, id)
+	} else {
+		heap.Fix(&x.h, 0)
+	}
+	return id, t, true
+}
+
+// indexHeap is a min-heap of items ordered by their next occurrence.
+type indexHeap []*indexItem
+
+func (h indexHeap) Len() int { return len(h) }
+
+func (h indexHeap) Less(i, j int) bool {
+	if h[i].next.Equal(h[j].next) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].next.Before(h[j].next)
+}
+
+func (h indexHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos = i
+	h[j].pos = j
+}
+
+func (h *indexHeap) Push(x interface{}) {
+	item := x.(*indexItem)
+	item.pos = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *indexHeap) Pop() interface{} {
+	old := *h
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return item
+}
+
+// A MatchIndex holds many Schedules, identified by string IDs, and finds
+// those which match a given time. Rather than testing each Schedule in turn,
+// it keeps, for each value of each field, the set of Schedules including
+// that value, and intersects five of these sets.
+//
+// A MatchIndex is not safe for concurrent use.
+type MatchIndex struct {
+	ids   []string // by slot; "" for a free slot
+	slots map[string]int
+	free  []int
+	sets  slotSets
+}
+
+// NewMatchIndex returns an empty MatchIndex.
+func NewMatchIndex() *MatchIndex {
+	return &MatchIndex{slots: make(map[string]int)}
+}
+
+// Len returns the number of Schedules in x.
+func (x *MatchIndex) Len() int {
+	return len(x.slots)
+}
+
+// Add adds s to x under the given ID, replacing any Schedule already added
+// with that ID. Add panics if id is empty or s is not valid.
+func (x *MatchIndex) Add(id string, s Schedule) {
+	if id == "" {
+		panic("cron: empty ID in MatchIndex.Add")
+	}
+	if !s.Valid() {
+		panic("MatchIndex.Add called with invalid schedule")
+	}
+	x.Remove(id)
+	var slot int
+	if n := len(x.free); n > 0 {
+		slot = x.free[n-1]
+		x.free = x.free[:n-1]
+		x.ids[slot] = id
+	} else {
+		slot = len(x.ids)
+		x.ids = append(x.ids, id)
+	}
+	x.slots[id] = slot
+	x.sets.set(slot, s)
+}
+
+// Remove removes the Schedule with the given ID from x. It reports whether
+// there was one.
+func (x *MatchIndex) Remove(id string) bool {
+	slot, ok := x.slots[id]
+	if !ok {
+		return false
+	}
+	x.sets.set(slot, Schedule{})
+	delete(x.slots, id)
+	x.ids[slot] = ""
+	x.free = append(x.free, slot)
+	return true
+}
+
+// Matching returns the IDs of the Schedules in x which match t, as by
+// Schedule.Matches, in an unspecified order.
+func (x *MatchIndex) Matching(t time.Time) []string {
+	var ids []string
+	for w, bits := range x.sets.match(t, nil) {
+		for ; bits != 0; bits &= bits - 1 {
+			ids = append(ids, x.ids[w*64+mathbits.TrailingZeros64(bits)])
+		}
+	}
+	return ids
+}
+
+// A Batch is a fixed list of Schedules stored so that they can be matched
+// against a time all at once. For each value of each field, a Batch packs
+// the bits of all of its Schedules into words, so that a few AND operations
+// per word match 64 Schedules. Use NewBatch to create a Batch.
+//
+// A Batch is safe for concurrent use.
+type Batch struct {
+	n    int
+	sets slotSets
+}
+
+// NewBatch returns a Batch of the given Schedules. NewBatch panics if any of
+// them is not valid.
+func NewBatch(schedules []Schedule) *Batch {
+	b := &Batch{n: len(schedules)}
+	for i, s := range schedules {
+		if !s.Valid() {
+			panic(fmt.Sprintf("NewBatch called with invalid schedule at index %d", i))
+		}
+		b.sets.set(i, s)
+	}
+	return b
+}
+
+// Len returns the number of Schedules in b.
+func (b *Batch) Len() int {
+	return b.n
+}
+
+// Matches reports which of b's Schedules match t, as by Schedule.Matches.
+// The result is a bitset: bit i%64 of word i/64 is set if the Schedule at
+// index i matches. Matches stores the result in dst if it has enough
+// capacity, so callers can reuse the same slice for every call.
+func (b *Batch) Matches(t time.Time, dst []uint64) []uint64 {
+	return b.sets.match(t, dst)
+}
+
+// slotSets holds, for each bit of a Schedule, a bitset of the slots whose
+// Schedules have that bit set.
+type slotSets [scheduleBits][]uint64
+
+// set sets the bits of slot to those of s.
+func (ss *slotSets) set(slot int, s Schedule) {
+	word, bit := slot/64, uint64(1)<<uint(slot%64)
+	for off := range ss {
+		for len(ss[off]) <= word {
+			ss[off] = append(ss[off], 0)
+		}
+		if s.isSet(off) {
+			ss[off][word] |= bit
+		} else {
+			ss[off][word] &^= bit
+		}
+	}
+}
+
+// match stores in dst, reusing its storage if possible, the bitset of slots
+// whose Schedules match t.
+func (ss *slotSets) match(t time.Time, dst []uint64) []uint64 {
+	_, month, day := t.Date()
+	hour, min, _ := t.Clock()
+	minSet := ss[minuteOffset+min]
+	hourSet := ss[hourOffset+hour]
+	domSet := ss[domOffset+day-1]
+	monthSet := ss[monthOffset+int(month)-1]
+	dowSet := ss[dowOffset+int(t.Weekday())]
+	dst = dst[:0]
+	for w := range minSet {
+		dst = append(dst, minSet[w]&hourSet[w]&domSet[w]&monthSet[w]&dowSet[w])
+	}
+	return dst
+}
@@ -0,0 +1,164 @@
+package cron
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSchedulerIndex(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	x := NewSchedulerIndex(start)
+	x.Add("quarter", mustParse(t, "*/15 * * * *"))
+	x.Add("half", mustParse(t, "*/30 * * * *"))
+	x.Add("ten", mustParse(t, "10 * * * *"))
+	x.Add("once", At(start.Add(20*time.Minute)))
+	x.Add("never", At(start)) // no occurrences after start
+	if got, want := x.Len(), 4; got != want {
+		t.Fatalf("Len() = %d; want %d", got, want)
+	}
+	if id, tm, ok := x.Peek(); !ok || id != "ten" || !tm.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("Peek() = %q, %s, %t; want ten at 00:10", id, tm, ok)
+	}
+
+	var got []string
+	for i := 0; i < 8; i++ {
+		id, tm, ok := x.Pop()
+		if !ok {
+			t.Fatal("Pop() reported false")
+		}
+		got = append(got, fmt.Sprintf("%s %s", tm.Format("15:04"), id))
+	}
+	want := []string{
+		"00:10 ten",
+		"00:15 quarter",
+		"00:20 once",
+		"00:30 quarter", // ties come out in the order added
+		"00:30 half",
+		"00:45 quarter",
+		"01:00 quarter",
+		"01:00 half",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Pop sequence (-got, +want):\n%s", diff)
+	}
+	if got, want := x.Len(), 3; got != want {
+		t.Errorf("after once fired, Len() = %d; want %d", got, want)
+	}
+
+	// Replacing and removing.
+	x.Add("half", mustParse(t, "5 * * * *"))
+	if !x.Remove("quarter") {
+		t.Error("Remove(quarter) = false")
+	}
+	if x.Remove("quarter") {
+		t.Error("second Remove(quarter) = true")
+	}
+	if id, tm, _ := x.Pop(); id != "half" || tm.Format("15:04") != "01:05" {
+		t.Errorf("Pop() = %q at %s; want half at 01:05", id, tm.Format("15:04"))
+	}
+	x.Remove("half")
+	x.Remove("ten")
+	if _, _, ok := x.Pop(); ok {
+		t.Error("Pop() on empty index reported true")
+	}
+}
+
+func TestSchedulerIndexNeverFires(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	x := NewSchedulerIndex(start)
+	x.Add("never", mustParse(t, "0 0 30 2 *"))
+	x.Add("zoned", mustParse(t, "0 0 31 4,6,9,11 *").In(time.UTC))
+	if got := x.Len(); got != 0 {
+		t.Errorf("Len() = %d; want 0", got)
+	}
+	x.Add("hourly", mustParse(t, "0 * * * *"))
+	if id, tm, ok := x.Pop(); !ok || id != "hourly" || !tm.Equal(start.Add(time.Hour)) {
+		t.Errorf("Pop() = %q, %s, %t; want hourly at 01:00", id, tm, ok)
+	}
+}
+
+func TestMatchIndex(t *testing.T) {
+	schedules := map[string]Schedule{
+		"every":    mustParse(t, "* * * * *"),
+		"hourly":   mustParse(t, "0 * * * *"),
+		"morning":  mustParse(t, "0 9 * * MON-FRI"),
+		"monthly":  mustParse(t, "0 0 1 * *"),
+		"quarters": mustParse(t, "*/15 * * * *"),
+	}
+	// Enough schedules to need several words per set.
+	for i := 0; i < 200; i++ {
+		schedules[fmt.Sprintf("filler%d", i)] = mustParse(t, fmt.Sprintf("%d 23 * * *", i%60))
+	}
+	x := NewMatchIndex()
+	for id, s := range schedules {
+		x.Add(id, s)
+	}
+	if got, want := x.Len(), len(schedules); got != want {
+		t.Errorf("Len() = %d; want %d", got, want)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		tm := time.Unix(r.Int63n(10*365*24*60)*60, 0).UTC()
+		if i%10 == 0 {
+			tm = time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC) // a Monday
+		}
+		var want []string
+		for id, s := range schedules {
+			if s.Matches(tm) {
+				want = append(want, id)
+			}
+		}
+		got := x.Matching(tm)
+		sort.Strings(got)
+		sort.Strings(want)
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Fatalf("Matching(%s) (-got, +want):\n%s", tm, diff)
+		}
+	}
+
+	x.Remove("every")
+	x.Add("hourly", mustParse(t, "30 * * * *"))
+	x.Add("new", mustParse(t, "0 0 * * *"))
+	got := x.Matching(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC))
+	sort.Strings(got)
+	if diff := cmp.Diff(got, []string{"monthly", "new", "quarters"}); diff != "" {
+		t.Errorf("after changes, Matching (-got, +want):\n%s", diff)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	var schedules []Schedule
+	for i := 0; i < 150; i++ {
+		expr := fmt.Sprintf("%d-59/%d %d-23/%d * * *", r.Intn(59), 1+r.Intn(30), r.Intn(23), 1+r.Intn(5))
+		schedules = append(schedules, mustParse(t, expr))
+	}
+	schedules = append(schedules, mustParse(t, "0 9 1 * MON"))
+	b := NewBatch(schedules)
+	if got, want := b.Len(), len(schedules); got != want {
+		t.Errorf("Len() = %d; want %d", got, want)
+	}
+	var dst []uint64
+	for i := 0; i < 1000; i++ {
+		tm := time.Unix(r.Int63n(10*365*24*60)*60, 0).UTC()
+		dst = b.Matches(tm, dst)
+		if got, want := len(dst), (len(schedules)+63)/64; got != want {
+			t.Fatalf("Matches returned %d words; want %d", got, want)
+		}
+		for j, s := range schedules {
+			got := dst[j/64]&(1<<uint(j%64)) != 0
+			if want := s.Matches(tm); got != want {
+				t.Fatalf("Matches(%s) has bit %d = %t; want %t (schedule %q)", tm, j, got, want, s)
+			}
+		}
+	}
+	if got := NewBatch(nil).Matches(time.Now(), nil); len(got) != 0 {
+		t.Errorf("empty Batch Matches = %v; want none", got)
+	}
+}
@@ -0,0 +1,33 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+// InferSchedule returns the most specific Schedule that fires at every one of
+// the given times. Each time is truncated to the minute and interpreted using
+// the wall clock of its own location.
+//
+// Because a Schedule restricts each field independently, the result fires at
+// every combination of the observed minutes, hours, days, months, and days of
+// the week, and each field includes only the values that were observed.
+// For example, if a job ran at 03:00 daily during a single week, the
+// inferred schedule is restricted to those seven days of the month. A
+// history spanning more time gives a more useful schedule.
+//
+// InferSchedule returns an error if times is empty.
+func InferSchedule(times []time.Time) (Schedule, error) {
+	if len(times) == 0 {
+		return Schedule{}, errors.New("cannot infer a cron schedule from no times")
+	}
+	var s Schedule
+	for _, t := range times {
+		s = s.set(minuteOffset + t.Minute())
+		s = s.set(hourOffset + t.Hour())
+		s = s.set(domOffset + t.Day() - 1)
+		s = s.set(monthOffset + int(t.Month()) - 1)
+		s = s.set(dowOffset + int(t.Weekday()))
+	}
+	return s, nil
+}
@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInferSchedule(t *testing.T) {
+	var times []time.Time
+	// Weekdays at 03:00 and 03:30 (with some start-up delay) over a year.
+	for d := time.Date(2020, 1, 1, 3, 0, 2, 0, time.UTC); d.Year() == 2020; d = d.AddDate(0, 0, 1) {
+		if d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			continue
+		}
+		times = append(times, d, d.Add(30*time.Minute))
+	}
+	s, err := InferSchedule(times)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.String(), "0,30 3 * * MON-FRI"; got != want {
+		t.Errorf("InferSchedule gave %q; want %q", got, want)
+	}
+	for _, tm := range times {
+		if !s.Matches(tm) {
+			t.Errorf("inferred schedule %q does not match %s", s, tm)
+		}
+	}
+
+	s, err = InferSchedule([]time.Time{time.Date(2020, 6, 9, 14, 5, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := s.String(), "5 14 9 JUN TUE"; got != want {
+		t.Errorf("InferSchedule gave %q; want %q", got, want)
+	}
+
+	if _, err := InferSchedule(nil); err == nil {
+		t.Error("InferSchedule(nil) succeeded; want error")
+	}
+}
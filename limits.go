@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Limits bounds the size of the cron expressions accepted by its Parse and
+// ParseH methods, for parsing expressions from untrusted sources, such as
+// the body of an API request.
+//
+// Parsing takes time and memory linear in the length of an expression, and
+// the errors returned quote at most the whole expression, so with a
+// MaxLength of n, the work done for any input, valid or not, is O(n). An
+// expression longer than MaxLength is rejected before it is examined
+// further.
+//
+// A zero field means no limit, so the zero Limits accepts whatever Parse
+// does.
+type Limits struct {
+	// MaxLength is the maximum length of an expression, in bytes.
+	MaxLength int
+	// MaxListElements is the maximum number of comma-separated elements
+	// in each field.
+	MaxListElements int
+}
+
+// DefaultLimits are limits which accept every schedule written with its
+// values listed one by one, such as "0,1,2,...,59 * * * *", and little
+// more.
+var DefaultLimits = Limits{MaxLength: 1024, MaxListElements: 64}
+
+// Parse is like the package-level Parse, but it first checks expr against
+// the limits.
+func (l Limits) Parse(expr string) (Schedule, error) {
+	if err := l.check(expr); err != nil {
+		return Schedule{}, err
+	}
+	return Parse(expr)
+}
+
+// ParseH is like the package-level ParseH, but it first checks expr against
+// the limits.
+func (l Limits) ParseH(expr string, seed uint64) (Schedule, error) {
+	if err := l.check(expr); err != nil {
+		return Schedule{}, err
+	}
+	return ParseH(expr, seed)
+}
+
+func (l Limits) check(expr string) error {
+	if l.MaxLength > 0 && len(expr) > l.MaxLength {
+		return fmt.Errorf("cron expression is %d bytes long, more than the limit of %d", len(expr), l.MaxLength)
+	}
+	if l.MaxListElements <= 0 {
+		return nil
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		// Leave the error to Parse.
+		return nil
+	}
+	for i, field := range fields {
+		if n := strings.Count(field, ",") + 1; n > l.MaxListElements {
+			return fmt.Errorf("the %s field has %d list elements, more than the limit of %d", fieldNames[i], n, l.MaxListElements)
+		}
+	}
+	return nil
+}
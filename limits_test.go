@@ -0,0 +1,78 @@
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLimits(t *testing.T) {
+	var all [5]string
+	for i, size := range fieldSizes {
+		vals := make([]string, size)
+		for j := range vals {
+			v := j
+			if i == 2 || i == 3 {
+				v++
+			}
+			vals[j] = strconv.Itoa(v)
+		}
+		all[i] = strings.Join(vals, ",")
+	}
+	for _, expr := range []string{
+		"*/5 * * * *",
+		"@daily",
+		strings.Join(all[:], " "),
+	} {
+		if _, err := DefaultLimits.Parse(expr); err != nil {
+			t.Errorf("DefaultLimits.Parse(%q): %s", expr, err)
+		}
+	}
+	if _, err := DefaultLimits.ParseH("H H * * *", 1); err != nil {
+		t.Errorf("DefaultLimits.ParseH: %s", err)
+	}
+
+	long := strings.Repeat("1,", 1000) + "1 * * * *"
+	for _, tt := range []struct {
+		limits Limits
+		expr   string
+		want   string
+	}{
+		{
+			DefaultLimits,
+			long,
+			"cron expression is 2009 bytes long, more than the limit of 1024",
+		},
+		{
+			Limits{MaxListElements: 3},
+			"0 1,2,3,4 * * *",
+			"the hour field has 4 list elements, more than the limit of 3",
+		},
+		{
+			Limits{MaxLength: 10},
+			"* * *",
+			`wrong number of fields in schedule "* * *" (expected 5)`,
+		},
+	} {
+		_, err := tt.limits.Parse(tt.expr)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%+v.Parse(%.20q...): got error %v; want %q", tt.limits, tt.expr, err, tt.want)
+		}
+	}
+
+	// The zero Limits accepts whatever Parse does.
+	if _, err := (Limits{}).Parse(long); err != nil {
+		t.Errorf("Limits{}.Parse: %s", err)
+	}
+}
+
+func TestParseLongInput(t *testing.T) {
+	// Parse must stay linear for inputs with many fields or list elements.
+	for _, expr := range []string{
+		strings.Repeat("* ", 1<<20),
+		strings.Repeat("1,", 1<<20) + "1 * * * *",
+		strings.Repeat("-", 1<<20),
+	} {
+		Parse(expr)
+	}
+}
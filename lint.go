@@ -0,0 +1,218 @@
+package cron
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// A Severity says how serious a Diagnostic is.
+type Severity int
+
+const (
+	// SeverityError means the line is invalid: cron rejects the file or
+	// ParseCrontab returns an error.
+	SeverityError Severity = iota + 1
+	// SeverityWarning means the line is valid but probably does not do
+	// what its author intended.
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// A Diagnostic describes a problem found by LintCrontab.
+type Diagnostic struct {
+	Line     int // starting at 1
+	Column   int // the byte offset in the line, starting at 1
+	Severity Severity
+	Message  string
+}
+
+// String formats d as "line:column: severity: message".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", d.Line, d.Column, d.Severity, d.Message)
+}
+
+// LintCrontab checks a crontab file, in the format given by opts as for
+// ParseCrontab, and returns the problems it finds, in order. Unlike
+// ParseCrontab, it does not stop at the first invalid line. Besides the
+// lines which ParseCrontab rejects, LintCrontab reports these errors:
+//
+//   - The file does not end with a newline. Cron ignores (or, in some
+//     versions, rejects) an unterminated last line.
+//
+// and these warnings:
+//
+//   - A line ends with a carriage return, which becomes part of the command.
+//   - A schedule never fires, such as "0 0 30 2 *".
+//   - A schedule restricts both the day of the month and the day of the
+//     week (that is, neither field begins with *). Cron runs such an entry
+//     on days matching either field, but a Schedule fires only on days
+//     matching both.
+//   - A command contains an unescaped %, which cron turns into a newline,
+//     passing the rest of the command to it as standard input.
+//   - An entry runs the same command at the same times, with the same
+//     environment, as an earlier one.
+//
+// LintCrontab returns an error only if reading r fails.
+func LintCrontab(r io.Reader, opts ...CrontabOption) ([]Diagnostic, error) {
+	var c crontabConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	lines := strings.Split(string(data), "\n")
+	unterminated := lines[len(lines)-1] != ""
+	if !unterminated {
+		lines = lines[:len(lines)-1]
+	}
+	system := c.format == SystemCrontab || c.format == DetectCrontab && isSystemCrontab(lines)
+
+	var (
+		diags   []Diagnostic
+		env     = make(map[string]string)
+		entries []Entry
+	)
+	report := func(line, col int, sev Severity, format string, args ...interface{}) {
+		diags = append(diags, Diagnostic{line, col, sev, fmt.Sprintf(format, args...)})
+	}
+	for i, line := range lines {
+		n := i + 1
+		if strings.HasSuffix(line, "\r") {
+			line = strings.TrimSuffix(line, "\r")
+			report(n, len(line)+1, SeverityWarning, "line ends with a carriage return")
+		}
+		fields := fieldColumns(line)
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			if text := commentText(trimmed); isMetadata(text) {
+				if err := parseMetadata(text, make(map[string]string)); err != nil {
+					report(n, fields[0], SeverityError, "%s", err)
+				}
+			}
+			continue
+		}
+		if trimmed == "" {
+			continue
+		}
+		if name, value, ok := parseEnvAssignment(trimmed); ok {
+			env[name] = value
+			if name == "CRON_TZ" || name == "TZ" {
+				if _, err := crontabLocation(env); err != nil {
+					report(n, strings.Index(line, "=")+2, SeverityError, "%s", err)
+				}
+			}
+			continue
+		}
+		e, _, err := parseCrontabEntry(trimmed, system)
+		if err != nil {
+			report(n, errorColumn(line, fields, system), SeverityError, "%s", err)
+			continue
+		}
+		e.Line = n
+		e.Env = make(map[string]string, len(env))
+		for name, value := range env {
+			e.Env[name] = value
+		}
+		if !e.Reboot && e.Schedule.normalize() == (Schedule{}) {
+			report(n, fields[0], SeverityWarning, "schedule never fires")
+		}
+		// Like cron, consider a field restricted unless it begins with *.
+		if words := strings.Fields(trimmed); !strings.HasPrefix(words[0], "@") &&
+			!strings.HasPrefix(words[2], "*") && !strings.HasPrefix(words[4], "*") {
+			report(n, fields[2], SeverityWarning,
+				"both day of month and day of week are restricted; cron runs the command on days matching either")
+		}
+		cmd := parseEntryLayout(line, system).command
+		if j := unescapedPercent(cmd); j >= 0 {
+			start := len(strings.TrimRight(line, " \t")) - len(cmd)
+			report(n, start+j+1, SeverityWarning,
+				"unescaped %% in command is a newline, and the text after it is standard input")
+		}
+		for _, prev := range entries {
+			if sameJob(prev, e) && prev.Reboot == e.Reboot && prev.Schedule.normalize() == e.Schedule.normalize() {
+				report(n, fields[0], SeverityWarning, "duplicate of the entry on line %d", prev.Line)
+				break
+			}
+		}
+		entries = append(entries, e)
+	}
+	if unterminated {
+		n := len(lines)
+		report(n, len(strings.TrimSuffix(lines[n-1], "\r"))+1, SeverityError, "missing newline at end of file")
+	}
+	return diags, nil
+}
+
+// fieldColumns returns the columns, starting at 1, at which the
+// space-separated fields of line begin.
+func fieldColumns(line string) []int {
+	var cols []int
+	for i := 0; i < len(line); i++ {
+		if line[i] != ' ' && line[i] != '\t' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t') {
+			cols = append(cols, i+1)
+		}
+	}
+	return cols
+}
+
+// errorColumn returns the column of the problem in line, an entry which
+// parseCrontabEntry rejects, whose fields begin at the given columns.
+func errorColumn(line string, fields []int, system bool) int {
+	end := len(strings.TrimRight(line, " \t")) + 1
+	words := strings.Fields(line)
+	if name := words[0]; strings.HasPrefix(name, "@") {
+		if _, ok := crontabSchedules[name]; !ok && name != "@reboot" {
+			if _, err := Parse(name); err != nil {
+				return fields[0]
+			}
+		}
+		return end
+	}
+	if len(words) < 6 {
+		return end
+	}
+	for i := 0; i < 5; i++ {
+		expr := make([]string, 5)
+		for j := range expr {
+			expr[j] = "*"
+		}
+		expr[i] = words[i]
+		if _, err := Parse(strings.Join(expr, " ")); err != nil {
+			return fields[i]
+		}
+	}
+	if system && len(words) < 7 {
+		return end
+	}
+	return fields[0]
+}
+
+// unescapedPercent returns the index of the first % in cmd which is not
+// preceded by a backslash, or -1 if there is none.
+func unescapedPercent(cmd string) int {
+	for i := 0; i < len(cmd); i++ {
+		switch cmd[i] {
+		case '\\':
+			i++
+		case '%':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,80 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLintCrontab(t *testing.T) {
+	const crontab = "# Comments and blank lines are fine.\n" +
+		"\n" +
+		"MAILTO=ops@example.com\n" +
+		"0 3 * * *  backup\n" +
+		"0 3 * * *\n" +
+		"  61 * * * * check\n" +
+		"0 3 * * MON,TUE,XYZ report\n" +
+		"@fortnightly backup\n" +
+		"CRON_TZ=Mars/Olympus_Mons\n" +
+		"0 0 30 2 * never\n" +
+		"0 0 1 * MON first\n" +
+		"0 0 */2 * MON every-other\n" +
+		"0 9 * * * date +%Y-%m-%d\n" +
+		"0 9 * * * echo 100\\%\n" +
+		"@daily\tbackup \n" +
+		"0 0 * * * backup\r\n" +
+		"@reboot start"
+	diags, err := LintCrontab(strings.NewReader(crontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range diags {
+		got = append(got, d.String())
+	}
+	want := []string{
+		"5:10: error: missing command",
+		"6:3: error: invalid value 61 for the minute field",
+		"7:9: error: " + parseErr(t, "0 3 * * MON,TUE,XYZ"),
+		"8:1: error: unrecognized cron schedule name: \"@fortnightly\"",
+		"9:9: error: unknown time zone Mars/Olympus_Mons",
+		"10:1: warning: schedule never fires",
+		"11:5: warning: both day of month and day of week are restricted; cron runs the command on days matching either",
+		"13:17: warning: unescaped % in command is a newline, and the text after it is standard input",
+		"16:17: warning: line ends with a carriage return",
+		"16:1: warning: duplicate of the entry on line 15",
+		"17:14: error: missing newline at end of file",
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("diagnostics (-got, +want):\n%s", diff)
+	}
+
+	// System crontabs need a user.
+	diags, err = LintCrontab(strings.NewReader("0 3 * * * root\n"), WithCrontabFormat(SystemCrontab))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 1 || diags[0].String() != "1:15: error: missing command" {
+		t.Errorf("system crontab: got %v", diags)
+	}
+	diags, err = LintCrontab(strings.NewReader("  # cron:name=x owner\n0 3 * * * backup\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) != 1 || diags[0].String() != `1:3: error: invalid metadata "owner" (want key=value)` {
+		t.Errorf("metadata: got %v", diags)
+	}
+	if diags, _ := LintCrontab(strings.NewReader("")); len(diags) != 0 {
+		t.Errorf("empty file: got %v", diags)
+	}
+}
+
+func parseErr(t *testing.T, expr string) string {
+	t.Helper()
+	_, err := Parse(expr)
+	if err == nil {
+		t.Fatalf("Parse(%q) succeeded", expr)
+	}
+	return err.Error()
+}
@@ -0,0 +1,99 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// A WeightedSchedule is a Schedule with a weight, such as the number of jobs
+// which use it or the cost of running its job, for SimulateLoad.
+type WeightedSchedule struct {
+	Schedule Schedule
+	Weight   float64
+}
+
+// A Load is the aggregate load of a set of schedules over a window of time,
+// as computed by SimulateLoad.
+type Load struct {
+	// From is the start of the first minute of the window.
+	From time.Time
+	// PerMinute holds the total weight of the schedules which fire in
+	// each minute of the window: PerMinute[i] is for the minute starting
+	// i minutes after From.
+	PerMinute []float64
+	// Busiest holds the minutes with the greatest load, busiest first.
+	// Minutes with the same load are in order of time.
+	Busiest []MinuteLoad
+}
+
+// A MinuteLoad is the load in a single minute.
+type MinuteLoad struct {
+	Time time.Time
+	Load float64
+}
+
+// SimulateLoad computes the load of schedules over the window of time from
+// from until to: the sum of the weights of the schedules which fire in each
+// minute, as given by EachBetween (so the schedules are interpreted in
+// from's location). It reports up to busiest of the busiest minutes, leaving
+// out those in which no schedule fires.
+//
+// Each distinct Schedule is evaluated once, however many times it appears in
+// schedules, so SimulateLoad is efficient for a large fleet of jobs which
+// share a smaller number of schedules, as is common.
+//
+// SimulateLoad panics if any schedule is not valid.
+func SimulateLoad(schedules []WeightedSchedule, from, to time.Time, busiest int) Load {
+	weights := make(map[Schedule]float64)
+	var distinct []Schedule // in order of appearance, for determinism
+	for _, ws := range schedules {
+		if err := ws.Schedule.Validate(); err != nil {
+			panic("SimulateLoad() called with invalid schedule: " + err.Error())
+		}
+		if _, ok := weights[ws.Schedule]; !ok {
+			distinct = append(distinct, ws.Schedule)
+		}
+		weights[ws.Schedule] += ws.Weight
+	}
+
+	start := from.Truncate(time.Minute)
+	if start.Before(from) {
+		start = start.Add(time.Minute)
+	}
+	l := Load{From: start}
+	if !to.After(start) {
+		return l
+	}
+	l.PerMinute = make([]float64, (to.Sub(start)+time.Minute-1)/time.Minute)
+	for _, s := range distinct {
+		if s.normalize() == (Schedule{}) {
+			continue // never fires
+		}
+		w := weights[s]
+		s.EachBetween(start, to, func(t time.Time) bool {
+			l.PerMinute[t.Sub(start)/time.Minute] += w
+			return true
+		})
+	}
+
+	var busy []int
+	for i, load := range l.PerMinute {
+		if load != 0 {
+			busy = append(busy, i)
+		}
+	}
+	sort.SliceStable(busy, func(i, j int) bool { return l.PerMinute[busy[i]] > l.PerMinute[busy[j]] })
+	if busiest < 0 {
+		busiest = 0
+	}
+	if len(busy) > busiest {
+		busy = busy[:busiest]
+	}
+	for _, i := range busy {
+		l.Busiest = append(l.Busiest, MinuteLoad{
+			Time: start.Add(time.Duration(i) * time.Minute),
+			Load: l.PerMinute[i],
+		})
+	}
+	return l
+}
@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSimulateLoad(t *testing.T) {
+	hourly := mustParse(t, "0 * * * *")
+	schedules := []WeightedSchedule{
+		{hourly, 1},
+		{mustParse(t, "*/15 * * * *"), 2},
+		{hourly, 3},
+		{mustParse(t, "30 1 * * *"), 0.5},
+		{mustParse(t, "0 0 30 2 *"), 100}, // never fires
+	}
+	from := time.Date(2026, 3, 1, 0, 59, 30, 0, time.UTC)
+	to := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	l := SimulateLoad(schedules, from, to, 3)
+
+	start := time.Date(2026, 3, 1, 1, 0, 0, 0, time.UTC)
+	if !l.From.Equal(start) {
+		t.Errorf("From = %s; want %s", l.From, start)
+	}
+	if len(l.PerMinute) != 60 {
+		t.Fatalf("got %d minutes; want 60", len(l.PerMinute))
+	}
+	want := map[int]float64{0: 6, 15: 2, 30: 2.5, 45: 2}
+	for i, load := range l.PerMinute {
+		if load != want[i] {
+			t.Errorf("PerMinute[%d] = %g; want %g", i, load, want[i])
+		}
+	}
+	wantBusiest := []MinuteLoad{
+		{start, 6},
+		{start.Add(30 * time.Minute), 2.5},
+		{start.Add(15 * time.Minute), 2},
+	}
+	if diff := cmp.Diff(wantBusiest, l.Busiest); diff != "" {
+		t.Errorf("Busiest: (-want, +got)\n%s", diff)
+	}
+
+	if l := SimulateLoad(schedules, to, from, 3); len(l.PerMinute) != 0 || len(l.Busiest) != 0 {
+		t.Errorf("SimulateLoad over an empty window gave %+v", l)
+	}
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A Locker coordinates Runners, typically in several replicas of a
+// program, so that only one of them runs each occurrence of a job (see
+// WithLocker). Jobs are identified by their names (see WithName).
+//
+// Locker implementations must be safe for concurrent use.
+type Locker interface {
+	// TryLock tries to acquire the lock for running the job with the given
+	// name at the occurrence t, without waiting for it. If it succeeds, it
+	// returns ok as true and a function which releases the lock; the
+	// Runner calls it after the run. If another holder has the lock, or
+	// has already run that occurrence, it returns ok as false.
+	//
+	// A Locker which ignores name gives a single lock for all jobs.
+	TryLock(ctx context.Context, name string, t time.Time) (release func(), ok bool, err error)
+}
+
+// WithLocker makes the Runner acquire a lock from l before each run of a
+// named job, and skip the run if the lock is not available. Jobs without
+// names are not locked.
+//
+// The Runner reports errors from l to the error handler (see
+// WithErrorHandler) and skips the run.
+func WithLocker(l Locker) RunnerOption {
+	return func(r *Runner) { r.locker = l }
+}
+
+// A MemoryLocker is a Locker for Runners in the same process. It lets each
+// job run at most one occurrence at a time, and each occurrence at most
+// once: once a job has been locked for an occurrence, TryLock fails for
+// that and earlier occurrences of it.
+type MemoryLocker struct {
+	mu   sync.Mutex
+	jobs map[string]*memoryLock
+}
+
+type memoryLock struct {
+	held bool
+	last time.Time // the latest occurrence locked
+}
+
+// NewMemoryLocker returns a MemoryLocker with no locks held.
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{jobs: make(map[string]*memoryLock)}
+}
+
+// TryLock implements Locker. It never returns an error.
+func (l *MemoryLocker) TryLock(_ context.Context, name string, t time.Time) (release func(), ok bool, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lk := l.jobs[name]
+	if lk == nil {
+		lk = new(memoryLock)
+		l.jobs[name] = lk
+	}
+	if lk.held || (!lk.last.IsZero() && !t.After(lk.last)) {
+		return nil, false, nil
+	}
+	lk.held = true
+	lk.last = t
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			lk.held = false
+			l.mu.Unlock()
+		})
+	}, true, nil
+}
@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryLocker(t *testing.T) {
+	l := NewMemoryLocker()
+	ctx := context.Background()
+	t1 := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	t2 := t1.AddDate(0, 0, 1)
+
+	release, ok, err := l.TryLock(ctx, "backup", t1)
+	if !ok || err != nil {
+		t.Fatalf("TryLock(backup, t1) = %t, %v; want success", ok, err)
+	}
+	if _, ok, _ := l.TryLock(ctx, "backup", t2); ok {
+		t.Error("TryLock(backup, t2) succeeded while t1 is held")
+	}
+	if _, ok, _ := l.TryLock(ctx, "report", t1); !ok {
+		t.Error("TryLock(report, t1) failed; want jobs locked separately")
+	}
+	release()
+	release() // no effect
+	if _, ok, _ := l.TryLock(ctx, "backup", t1); ok {
+		t.Error("TryLock(backup, t1) succeeded after t1 ran")
+	}
+	release2, ok, _ := l.TryLock(ctx, "backup", t2)
+	if !ok {
+		t.Error("TryLock(backup, t2) failed after t1 was released")
+	} else {
+		release2()
+	}
+}
+
+type errLocker struct{}
+
+func (errLocker) TryLock(context.Context, string, time.Time) (func(), bool, error) {
+	return nil, false, errors.New("unavailable")
+}
+
+func TestRunnerLocker(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	locker := NewMemoryLocker()
+	var mu sync.Mutex
+	var runs []string
+	var locked int
+	logger := LoggerFunc(func(ev Event) {
+		if ev.Kind == EventLocked {
+			mu.Lock()
+			locked++
+			mu.Unlock()
+		}
+	})
+	hourly := mustParse(t, "0 * * * *")
+	var replicas []*Runner
+	for _, name := range []string{"a", "b", "c"} {
+		name := name
+		r := NewRunner(WithClock(clock), WithLocker(locker), WithLogger(logger))
+		r.AddFunc(hourly, func() {
+			mu.Lock()
+			runs = append(runs, name)
+			mu.Unlock()
+		}, WithName("hourly"))
+		r.Start()
+		defer r.Stop()
+		replicas = append(replicas, r)
+	}
+	for i := 1; i <= 3; i++ {
+		waitFor(t, "timers", func() bool { return clock.pending() == len(replicas) })
+		clock.advance(time.Hour)
+		waitFor(t, "runs", func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return len(runs)+locked == i*len(replicas)
+		})
+	}
+	for _, r := range replicas {
+		r.Wait(context.Background())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(runs) != 3 {
+		t.Errorf("3 occurrences ran %d times (by %v); want once each", len(runs), runs)
+	}
+
+	errs := make(chan error, 1)
+	r := NewRunner(WithLocker(errLocker{}), WithErrorHandler(func(_ JobID, err error) { errs <- err }))
+	r.AddFunc(At(time.Now().Add(10*time.Millisecond)), func() { t.Error("job ran without its lock") }, WithName("job"))
+	r.Start()
+	defer r.Stop()
+	select {
+	case err := <-errs:
+		if want := "locking job: unavailable"; err.Error() != want {
+			t.Errorf("error handler got %q; want %q", err, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for locking error")
+	}
+}
@@ -0,0 +1,103 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A Logger receives Events describing what a Runner does. Log may be called
+// concurrently from several goroutines.
+type Logger interface {
+	Log(Event)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(Event)
+
+// Log calls f(ev).
+func (f LoggerFunc) Log(ev Event) { f(ev) }
+
+// An EventKind says what an Event describes.
+type EventKind int
+
+const (
+	// EventScheduled means the job's next occurrence was computed. If the
+	// Recurrence has no more occurrences, the Occurrence is zero.
+	EventScheduled EventKind = iota + 1
+	// EventStarted means a run of the job started.
+	EventStarted
+	// EventFinished means a run of the job finished.
+	EventFinished
+	// EventSkipped means an occurrence was skipped, either because a
+	// previous run was still in progress (see OverlapSkip) or because of
+	// Runner.SkipUntil, Runner.SkipNext, or Runner.Pause.
+	EventSkipped
+	// EventDelayed means an occurrence was delayed because a previous run
+	// was still in progress (see OverlapDelay).
+	EventDelayed
+	// EventLocked means an occurrence was skipped because its lock was
+	// held elsewhere (see WithLocker).
+	EventLocked
+)
+
+var eventKindNames = []string{
+	EventScheduled: "scheduled",
+	EventStarted:   "started",
+	EventFinished:  "finished",
+	EventSkipped:   "skipped",
+	EventDelayed:   "delayed",
+	EventLocked:    "locked",
+}
+
+func (k EventKind) String() string {
+	if k > 0 && int(k) < len(eventKindNames) {
+		return eventKindNames[k]
+	}
+	return "EventKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// An Event describes something a Runner did with a job.
+type Event struct {
+	Kind EventKind
+	Time time.Time // when the event happened
+	ID   JobID
+	Name string // the job's name, if it has one (see WithName)
+	// Occurrence is the occurrence of the job's Recurrence which the event
+	// concerns: the one just computed for EventScheduled and the one being
+	// run, skipped, or delayed otherwise.
+	Occurrence time.Time
+	// For EventFinished, Duration is how long the run took and Err is the
+	// error it returned, if any.
+	Duration time.Duration
+	Err      error
+}
+
+// String formats ev on one line, such as
+//
+//	job 3 (backup) finished after 1.5s: disk full
+func (ev Event) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "job %d", ev.ID)
+	if ev.Name != "" {
+		fmt.Fprintf(&b, " (%s)", ev.Name)
+	}
+	b.WriteString(" " + ev.Kind.String())
+	switch ev.Kind {
+	case EventScheduled:
+		if ev.Occurrence.IsZero() {
+			b.WriteString(" with no more occurrences")
+		} else {
+			b.WriteString(" for " + ev.Occurrence.Format(time.RFC3339))
+		}
+	case EventFinished:
+		b.WriteString(" after " + ev.Duration.String())
+		if ev.Err != nil {
+			b.WriteString(": " + ev.Err.Error())
+		}
+	default:
+		b.WriteString(" at " + ev.Occurrence.Format(time.RFC3339))
+	}
+	return b.String()
+}
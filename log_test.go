@@ -0,0 +1,32 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEventString(t *testing.T) {
+	occ := time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		ev   Event
+		want string
+	}{
+		{Event{Kind: EventScheduled, ID: 1, Occurrence: occ}, "job 1 scheduled for 2026-03-01T02:00:00Z"},
+		{Event{Kind: EventScheduled, ID: 1}, "job 1 scheduled with no more occurrences"},
+		{Event{Kind: EventStarted, ID: 2, Name: "backup", Occurrence: occ}, "job 2 (backup) started at 2026-03-01T02:00:00Z"},
+		{Event{Kind: EventFinished, ID: 2, Name: "backup", Duration: 1500 * time.Millisecond}, "job 2 (backup) finished after 1.5s"},
+		{
+			Event{Kind: EventFinished, ID: 3, Duration: time.Second, Err: errors.New("disk full")},
+			"job 3 finished after 1s: disk full",
+		},
+		{Event{Kind: EventSkipped, ID: 4, Occurrence: occ}, "job 4 skipped at 2026-03-01T02:00:00Z"},
+		{Event{Kind: EventDelayed, ID: 4, Occurrence: occ}, "job 4 delayed at 2026-03-01T02:00:00Z"},
+		{Event{Kind: EventLocked, ID: 4, Occurrence: occ}, "job 4 locked at 2026-03-01T02:00:00Z"},
+		{Event{Kind: 99, ID: 5, Occurrence: occ}, "job 5 EventKind(99) at 2026-03-01T02:00:00Z"},
+	} {
+		if got := tt.ev.String(); got != tt.want {
+			t.Errorf("String() = %q; want %q", got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cron
+
+// A MergeOption configures MergeCrontabs.
+type MergeOption func(*mergeConfig)
+
+type mergeConfig struct {
+	union bool
+}
+
+// WithScheduleUnion makes MergeCrontabs combine entries which run the same
+// job on different schedules into one entry, when a single Schedule fires at
+// all of their times and no others. That is the case when one schedule's
+// times include the other's, or when the schedules differ in only one
+// field: "0 3 * * MON" and "0 3 * * FRI" combine into "0 3 * * MON,FRI",
+// but "0 3 * * *" and "30 4 * * *" do not combine.
+func WithScheduleUnion() MergeOption {
+	return func(c *mergeConfig) { c.union = true }
+}
+
+// A Duplicate describes an entry dropped by MergeCrontabs.
+type Duplicate struct {
+	Source int // the index in sources of the entry's crontab
+	Entry  Entry
+	// Kept is the index in the merged entries of the entry which
+	// duplicates this one or into which it was combined.
+	Kept int
+}
+
+// MergeCrontabs merges the entries of several crontabs, such as those
+// returned by ParseCrontab, into one list. The entries are kept in order,
+// apart from duplicates: an entry is dropped if an earlier entry runs the
+// same job (the same command as the same user with the same environment)
+// at the same times, even if their schedules are written differently, such
+// as "0 0 * * 0" and "@weekly". MergeCrontabs returns the merged entries
+// and the dropped duplicates.
+func MergeCrontabs(sources [][]Entry, opts ...MergeOption) ([]Entry, []Duplicate) {
+	var c mergeConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	var (
+		merged []Entry
+		dups   []Duplicate
+	)
+	for src, entries := range sources {
+	entryLoop:
+		for _, e := range entries {
+			for i := range merged {
+				kept := &merged[i]
+				if !sameJob(*kept, e) || kept.Reboot != e.Reboot {
+					continue
+				}
+				if e.Reboot || e.Schedule.normalize() == kept.Schedule.normalize() {
+					dups = append(dups, Duplicate{Source: src, Entry: e, Kept: i})
+					continue entryLoop
+				}
+				if c.union {
+					if u, ok := unionSchedules(kept.Schedule, e.Schedule); ok {
+						kept.Schedule = u
+						dups = append(dups, Duplicate{Source: src, Entry: e, Kept: i})
+						continue entryLoop
+					}
+				}
+			}
+			merged = append(merged, e)
+		}
+	}
+	return merged, dups
+}
+
+// sameJob reports whether e1 and e2 run the same command as the same user
+// with the same environment.
+func sameJob(e1, e2 Entry) bool {
+	return e1.Command == e2.Command && e1.Stdin == e2.Stdin && e1.User == e2.User && sameEnv(e1.Env, e2.Env)
+}
+
+// unionSchedules returns the Schedule which fires at the times of both s1
+// and s2, if there is one.
+func unionSchedules(s1, s2 Schedule) (Schedule, bool) {
+	switch {
+	case s1.within(s2):
+		return s2, true
+	case s2.within(s1):
+		return s1, true
+	}
+	// A Schedule's times are the combinations of its fields' values
+	// (restricted to real dates), so if the schedules differ in only one
+	// field, the union of that field gives the union of their times.
+	f1, f2 := s1.fieldBits(), s2.fieldBits()
+	differ := 0
+	for i := range f1 {
+		if f1[i] != f2[i] {
+			differ++
+		}
+	}
+	if differ > 1 {
+		return Schedule{}, false
+	}
+	return s1.union(s2), true
+}
@@ -0,0 +1,117 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeCrontabs(t *testing.T) {
+	parse := func(crontab string) []Entry {
+		t.Helper()
+		entries, err := ParseCrontab(strings.NewReader(crontab))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entries
+	}
+	sources := [][]Entry{
+		parse(`0 0 * * 0 backup
+0 3 * * MON report
+@reboot start-agent
+`),
+		parse(`@weekly backup
+0 3 * * FRI report
+0 3 1-7 * MON report
+@reboot start-agent
+MAILTO=""
+0 0 * * 0 backup
+`),
+		parse(`30 4 * * * report
+0 0 * 2 * cleanup
+0 0 1-29 2 * cleanup
+`),
+	}
+	type entry struct {
+		Schedule string
+		Command  string
+	}
+	describe := func(entries []Entry) []entry {
+		var got []entry
+		for _, e := range entries {
+			got = append(got, entry{e.Recurrence().(fmt.Stringer).String(), e.Command})
+		}
+		return got
+	}
+	type dup struct {
+		Source, Line, Kept int
+	}
+	describeDups := func(dups []Duplicate) []dup {
+		var got []dup
+		for _, d := range dups {
+			got = append(got, dup{d.Source, d.Entry.Line, d.Kept})
+		}
+		return got
+	}
+
+	merged, dups := MergeCrontabs(sources)
+	want := []entry{
+		{"0 0 * * SUN", "backup"},
+		{"0 3 * * MON", "report"},
+		{"@reboot", "start-agent"},
+		{"0 3 * * FRI", "report"},
+		{"0 3 1-7 * MON", "report"},
+		{"0 0 * * SUN", "backup"}, // a different environment
+		{"30 4 * * *", "report"},
+		{"0 0 * FEB *", "cleanup"},
+	}
+	if diff := cmp.Diff(describe(merged), want); diff != "" {
+		t.Errorf("merged entries (-got, +want):\n%s", diff)
+	}
+	wantDups := []dup{{1, 1, 0}, {1, 4, 2}, {2, 3, 7}}
+	if diff := cmp.Diff(describeDups(dups), wantDups); diff != "" {
+		t.Errorf("duplicates (-got, +want):\n%s", diff)
+	}
+
+	merged, dups = MergeCrontabs(sources, WithScheduleUnion())
+	want = []entry{
+		{"0 0 * * SUN", "backup"},
+		{"0 3 * * MON,FRI", "report"},
+		{"@reboot", "start-agent"},
+		{"0 0 * * SUN", "backup"},
+		{"30 4 * * *", "report"},
+		{"0 0 * FEB *", "cleanup"},
+	}
+	if diff := cmp.Diff(describe(merged), want); diff != "" {
+		t.Errorf("with union, merged entries (-got, +want):\n%s", diff)
+	}
+	wantDups = []dup{{1, 1, 0}, {1, 2, 1}, {1, 3, 1}, {1, 4, 2}, {2, 3, 5}}
+	if diff := cmp.Diff(describeDups(dups), wantDups); diff != "" {
+		t.Errorf("with union, duplicates (-got, +want):\n%s", diff)
+	}
+}
+
+func TestUnionSchedules(t *testing.T) {
+	for _, tt := range []struct {
+		s1, s2 string
+		want   string // "" if there is no union
+	}{
+		{"0 3 * * MON", "0 3 * * FRI", "0 3 * * MON,FRI"},
+		{"0 3 * * *", "0 3 * * FRI", "0 3 * * *"},
+		{"0 3 1-7 * MON", "0 3 * * *", "0 3 * * *"},
+		{"0 3 * * *", "30 4 * * *", ""},
+		{"0 3 1 * *", "0 4 * * MON", ""},
+		{"*/15 * * * *", "*/10 * * * *", "0,10,15,20,30,40,45,50 * * * *"},
+	} {
+		u, ok := unionSchedules(mustParse(t, tt.s1), mustParse(t, tt.s2))
+		var got string
+		if ok {
+			got = u.String()
+		}
+		if got != tt.want {
+			t.Errorf("unionSchedules(%q, %q) = %q; want %q", tt.s1, tt.s2, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+)
+
+// A Policy limits how often a Schedule may fire. It is meant for checking
+// schedules supplied by untrusted users, such as the tenants of a shared
+// service. The zero Policy allows every valid Schedule.
+//
+// The limits are checked analytically, from the schedule's fields, rather
+// than by sampling the times at which a schedule fires.
+type Policy struct {
+	// MaxPerHour, if positive, is the most times the schedule may fire in
+	// any 60-minute period.
+	MaxPerHour int
+	// MaxPerDay, if positive, is the most times the schedule may fire in
+	// any 24-hour period.
+	MaxPerDay int
+	// MinInterval, if positive, is the shortest time allowed between
+	// consecutive times at which the schedule fires.
+	MinInterval time.Duration
+}
+
+// A PolicyError is returned by Policy.Check for a schedule which fires more
+// often than the Policy allows.
+type PolicyError struct {
+	Period string // the period of the limit: "hour" or "day"
+	Limit  int    // the most times the Policy allows the schedule to fire in Period
+	Count  int    // the most times the schedule fires in Period
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("cron schedule fires up to %d times per %s, more than the limit of %d", e.Count, e.Period, e.Limit)
+}
+
+// An IntervalError is returned by Policy.Check for a schedule which fires
+// twice within the Policy's MinInterval.
+type IntervalError struct {
+	MinInterval time.Duration // the Policy's MinInterval
+	// First and Second are consecutive times at which the schedule fires,
+	// in UTC, which are as close together as any.
+	First, Second time.Time
+}
+
+func (e *IntervalError) Error() string {
+	return fmt.Sprintf("cron schedule fires at %s and then at %s, %s apart, less than the minimum of %s",
+		e.First.Format("2006-01-02 15:04"), e.Second.Format("2006-01-02 15:04"), e.Second.Sub(e.First), e.MinInterval)
+}
+
+// Check reports whether s satisfies p. It returns the error given by
+// s.Validate if s is not valid, a *PolicyError if s fires too often, an
+// *IntervalError if s fires twice too close together, and nil otherwise. A
+// schedule which never fires satisfies every Policy.
+//
+// A schedule fires at the same minutes of every hour in which it fires, so
+// the most times it fires in any 60-minute period is the number of its
+// minutes, and similarly, the most times it fires in any 24-hour period is
+// the number of its minutes times the number of its hours. (Wall clock
+// changes for daylight saving time are not considered.)
+//
+// The shortest interval is found from the gaps between the schedule's
+// minutes and hours, and between the last time it fires on one day and the
+// first time on the next day on which it fires. The counterexample in an
+// *IntervalError is the first such pair from the current day on.
+func (p Policy) Check(s Schedule) error {
+	return p.check(s, time.Now())
+}
+
+func (p Policy) check(s Schedule, now time.Time) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if s.normalize() == (Schedule{}) {
+		return nil
+	}
+	mins, _ := s.Minutes()
+	hrs, _ := s.Hours()
+	for _, l := range []struct {
+		period string
+		limit  int
+		count  int
+	}{
+		{"hour", p.MaxPerHour, len(mins)},
+		{"day", p.MaxPerDay, len(mins) * len(hrs)},
+	} {
+		if l.limit > 0 && l.count > l.limit {
+			return &PolicyError{Period: l.period, Limit: l.limit, Count: l.count}
+		}
+	}
+	if p.MinInterval > 0 {
+		if first, second := s.closestTimes(now); second.Sub(first) < p.MinInterval {
+			return &IntervalError{MinInterval: p.MinInterval, First: first, Second: second}
+		}
+	}
+	return nil
+}
+
+// closestTimes returns the first pair of consecutive times, in UTC, on or
+// after the day of from, at which s fires with the shortest interval
+// between them. s must fire.
+func (s Schedule) closestTimes(from time.Time) (first, second time.Time) {
+	mins, _ := s.Minutes()
+	hrs, _ := s.Hours()
+	lastMin, lastHour := mins[len(mins)-1], hrs[len(hrs)-1]
+	day0 := s.nextUTC(from.UTC().Truncate(24 * time.Hour).Add(-time.Minute)).Truncate(24 * time.Hour)
+	at := func(day time.Time, hour, min int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(min)*time.Minute)
+	}
+	shortest := time.Duration(1<<63 - 1)
+	try := func(t1, t2 time.Time) {
+		if d := t2.Sub(t1); d < shortest {
+			shortest, first, second = d, t1, t2
+		}
+	}
+	// The gaps within a day are the same on every day on which s fires.
+	for i := 1; i < len(mins); i++ {
+		try(at(day0, hrs[0], mins[i-1]), at(day0, hrs[0], mins[i]))
+	}
+	for i := 1; i < len(hrs); i++ {
+		try(at(day0, hrs[i-1], lastMin), at(day0, hrs[i], mins[0]))
+	}
+	// The gap between days is least when s fires on consecutive days.
+	dayGap := 24*time.Hour - at(day0, lastHour, lastMin).Sub(at(day0, hrs[0], mins[0]))
+	if shortest <= dayGap {
+		return first, second
+	}
+	// Otherwise, look at the days on which s fires. They repeat every
+	// week if s restricts only the day of the week, and otherwise every
+	// 400 years, like the Gregorian calendar.
+	_, allDays := s.DaysOfMonth()
+	_, allMonths := s.Months()
+	end := day0.AddDate(400, 0, 0)
+	if allDays && allMonths {
+		end = day0.AddDate(0, 0, 7)
+	}
+	for day := day0; day.Before(end); {
+		last := at(day, lastHour, lastMin)
+		next := s.nextUTC(last)
+		try(last, next)
+		if shortest == dayGap {
+			break
+		}
+		day = next.Truncate(24 * time.Hour)
+	}
+	return first, second
+}
@@ -0,0 +1,112 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	p := Policy{MaxPerHour: 4, MaxPerDay: 24}
+	for _, tt := range []struct {
+		expr string
+		want *PolicyError
+	}{
+		{"0 * * * *", nil},
+		{"*/15 * * * *", &PolicyError{Period: "day", Limit: 24, Count: 96}},
+		{"*/15 9-12 * * *", nil},
+		{"*/15 9-17 * * MON-FRI", &PolicyError{Period: "day", Limit: 24, Count: 36}},
+		{"*/10 3 * * *", &PolicyError{Period: "hour", Limit: 4, Count: 6}},
+		{"* * 30 2 *", nil}, // never fires
+	} {
+		err := p.Check(mustParse(t, tt.expr))
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("Check(%q): %s", tt.expr, err)
+			}
+			continue
+		}
+		var pe *PolicyError
+		if !errors.As(err, &pe) {
+			t.Errorf("Check(%q): got error %v; want a *PolicyError", tt.expr, err)
+			continue
+		}
+		if diff := cmp.Diff(pe, tt.want); diff != "" {
+			t.Errorf("Check(%q): (-got, +want):\n%s", tt.expr, diff)
+		}
+	}
+
+	if err := (Policy{}).Check(mustParse(t, "* * * * *")); err != nil {
+		t.Errorf("zero Policy: Check(\"* * * * *\"): %s", err)
+	}
+	if err := p.Check(Schedule{}); err == nil {
+		t.Error("Check(Schedule{}) = nil; want error")
+	}
+	const want = "cron schedule fires up to 6 times per hour, more than the limit of 4"
+	if err := p.Check(mustParse(t, "*/10 3 * * *")); err == nil || err.Error() != want {
+		t.Errorf("got error %v; want %q", err, want)
+	}
+}
+
+func TestPolicyMinInterval(t *testing.T) {
+	from := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC) // a Thursday
+	p := Policy{MinInterval: 2 * time.Hour}
+	jan := func(day, hour, min int) time.Time {
+		return time.Date(2026, 1, day, hour, min, 0, 0, time.UTC)
+	}
+	for _, tt := range []struct {
+		expr          string
+		first, second time.Time // zero if the policy is satisfied
+	}{
+		{"*/15 * * * *", jan(1, 0, 0), jan(1, 0, 15)},
+		{"0,50 3,4 * * *", jan(1, 3, 50), jan(1, 4, 0)},
+		{"30 23 * * *", time.Time{}, time.Time{}},
+		{"50 0,23 * * *", jan(1, 23, 50), jan(2, 0, 50)},
+		{"0 0,23 * * MON,TUE", jan(5, 23, 0), jan(6, 0, 0)},
+		{"0 0,23 * * MON,WED", time.Time{}, time.Time{}},
+		{"0 23 * * MON", time.Time{}, time.Time{}},
+		{"0 23 * * 1-5", time.Time{}, time.Time{}},
+		{"30 23 1 * *", time.Time{}, time.Time{}},
+		{"0 0 29 2 *", time.Time{}, time.Time{}},
+	} {
+		err := p.check(mustParse(t, tt.expr), from)
+		if tt.first.IsZero() {
+			if err != nil {
+				t.Errorf("check(%q): %s", tt.expr, err)
+			}
+			continue
+		}
+		want := &IntervalError{MinInterval: p.MinInterval, First: tt.first, Second: tt.second}
+		var ie *IntervalError
+		if !errors.As(err, &ie) {
+			t.Errorf("check(%q): got error %v; want a *IntervalError", tt.expr, err)
+			continue
+		}
+		if diff := cmp.Diff(ie, want); diff != "" {
+			t.Errorf("check(%q): (-got, +want):\n%s", tt.expr, diff)
+		}
+	}
+
+	// The shortest gaps between days.
+	for _, tt := range []struct {
+		expr string
+		want time.Duration
+	}{
+		{"0 9 * * MON,WED", 48 * time.Hour},
+		{"0 9 1,15 * *", 14 * 24 * time.Hour},
+		{"0 0 29 2 *", 1461 * 24 * time.Hour},
+		{"0 0 31 * *", 31 * 24 * time.Hour},
+	} {
+		first, second := mustParse(t, tt.expr).closestTimes(from)
+		if got := second.Sub(first); got != tt.want {
+			t.Errorf("closestTimes(%q): got %s to %s, %s apart; want %s apart", tt.expr, first, second, got, tt.want)
+		}
+	}
+	err := Policy{MinInterval: time.Hour}.check(mustParse(t, "0,30 9 * * *"), from)
+	const want = "cron schedule fires at 2026-01-01 09:00 and then at 2026-01-01 09:30, 30m0s apart, less than the minimum of 1h0m0s"
+	if err == nil || err.Error() != want {
+		t.Errorf("got error %v; want %q", err, want)
+	}
+}
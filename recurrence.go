@@ -0,0 +1,292 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A Recurrence is a sequence of times at which something happens.
+//
+// Schedule is the most common Recurrence. The other implementations in this
+// package express sequences that a cron expression cannot.
+type Recurrence interface {
+	// Next returns the earliest occurrence after t, in t's location.
+	// If there are no occurrences after t, Next returns the zero Time.
+	Next(t time.Time) time.Time
+}
+
+// Once is a Recurrence with a single occurrence. Use At to create a Once.
+type Once struct {
+	t time.Time
+}
+
+// At returns a Recurrence that occurs once, at t.
+func At(t time.Time) Once {
+	return Once{t}
+}
+
+// Next returns o's occurrence if it is after t and the zero Time otherwise.
+func (o Once) Next(t time.Time) time.Time {
+	if o.t.After(t) {
+		return o.t.In(t.Location())
+	}
+	return time.Time{}
+}
+
+// Reboot is a Recurrence with no occurrences, like a crontab's @reboot
+// entries. A Runner runs a job whose Recurrence is Reboot once, when the
+// Runner first starts, subject to the same options and policies as its
+// other jobs. Reboot jobs added after that do not run unless triggered by
+// RunNow or by another job (see After).
+type Reboot struct{}
+
+// Next returns the zero Time.
+func (Reboot) Next(time.Time) time.Time { return time.Time{} }
+
+func (Reboot) String() string { return "@reboot" }
+
+// An Interval is a Recurrence that occurs at a start time and then at fixed
+// intervals thereafter, such as "every 10 days starting 2026-01-01". A cron
+// expression can't express this because its steps restart at the beginning
+// of each hour, day, month, or week. Use EveryFrom or EveryDaysFrom to
+// create an Interval. The zero Interval has no occurrences.
+type Interval struct {
+	start time.Time
+	d     time.Duration
+	days  int
+}
+
+// EveryFrom returns an Interval that occurs at start and every d thereafter.
+// The interval is measured in elapsed time, so the wall clock time of the
+// occurrences shifts when the UTC offset of start's location changes (as it
+// does for daylight saving time). EveryFrom panics if d is not positive.
+func EveryFrom(start time.Time, d time.Duration) Interval {
+	if d <= 0 {
+		panic("cron: non-positive interval for EveryFrom")
+	}
+	return Interval{start: start, d: d}
+}
+
+// EveryDaysFrom returns an Interval that occurs at start and then every n
+// days at the same wall clock time in start's location. EveryDaysFrom panics
+// if n is not positive.
+func EveryDaysFrom(start time.Time, n int) Interval {
+	if n <= 0 {
+		panic("cron: non-positive interval for EveryDaysFrom")
+	}
+	return Interval{start: start, days: n}
+}
+
+// Next returns the earliest occurrence of iv after t, or the zero Time if iv
+// is the zero Interval.
+func (iv Interval) Next(t time.Time) time.Time {
+	if iv.d == 0 && iv.days == 0 {
+		return time.Time{}
+	}
+	if t.Before(iv.start) {
+		return iv.start.In(t.Location())
+	}
+	if iv.d > 0 {
+		k := t.Sub(iv.start)/iv.d + 1
+		return iv.start.Add(k * iv.d).In(t.Location())
+	}
+	// Count whole calendar days from start to t, rounding down to a
+	// multiple of the interval.
+	days := civilDays(t.In(iv.start.Location())) - civilDays(iv.start)
+	k := days / iv.days * iv.days
+	for {
+		next := iv.start.AddDate(0, 0, k)
+		if next.After(t) {
+			return next.In(t.Location())
+		}
+		k += iv.days
+	}
+}
+
+// civilDays returns the number of days between the Unix epoch and t's date
+// (in t's location).
+func civilDays(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
+
+// Bounded is a Recurrence whose occurrences are restricted to a window of
+// time. Use Between to create a Bounded.
+type Bounded struct {
+	r         Recurrence
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// Between returns a Recurrence with the occurrences of r that are no earlier
+// than notBefore and no later than notAfter. A zero notBefore or notAfter
+// leaves the window open at that end.
+func Between(r Recurrence, notBefore, notAfter time.Time) Bounded {
+	return Bounded{r: r, notBefore: notBefore, notAfter: notAfter}
+}
+
+// Next returns the earliest occurrence of b after t.
+func (b Bounded) Next(t time.Time) time.Time {
+	if !b.notBefore.IsZero() && t.Before(b.notBefore) {
+		t = b.notBefore.Add(-time.Nanosecond).In(t.Location())
+	}
+	next := b.r.Next(t)
+	if next.IsZero() || (!b.notAfter.IsZero() && next.After(b.notAfter)) {
+		return time.Time{}
+	}
+	return next
+}
+
+// Union is a Recurrence that occurs whenever any of its members occurs.
+type Union []Recurrence
+
+// ParseUnion parses several cron expressions, separated by semicolons or
+// newlines, as a single Recurrence: for example,
+//
+//	0 9 * * MON-FRI; 0 10 * * SAT
+//
+// fires at 09:00 on weekdays and at 10:00 on Saturdays. Each expression is
+// parsed with Parse. Empty expressions (such as blank lines) are ignored.
+func ParseUnion(expr string) (Union, error) {
+	var u Union
+	for _, e := range strings.FieldsFunc(expr, func(r rune) bool { return r == ';' || r == '\n' }) {
+		if strings.TrimSpace(e) == "" {
+			continue
+		}
+		s, err := Parse(strings.TrimSpace(e))
+		if err != nil {
+			return nil, err
+		}
+		u = append(u, s)
+	}
+	if len(u) == 0 {
+		return nil, fmt.Errorf("no cron expressions in %q", expr)
+	}
+	return u, nil
+}
+
+// Next returns the earliest occurrence of any member of u after t. Members
+// with no occurrence after t, such as schedules that never fire, are
+// ignored; if no member has one, Next returns the zero Time.
+func (u Union) Next(t time.Time) time.Time {
+	var next time.Time
+	for _, r := range u {
+		n := r.Next(t)
+		if !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// Exclusion is a Recurrence with the occurrences of a base Recurrence that
+// don't fall in a minute matched by an exclusion Schedule. Use Except to
+// create an Exclusion.
+type Exclusion struct {
+	base    Recurrence
+	exclude Schedule
+}
+
+// Except returns a Recurrence with the occurrences of base except those in
+// minutes matched by exclude. For example, an hourly job that must not run
+// during a maintenance window from 02:00 to 03:00 could use
+//
+//	cron.Except(hourly, maintenance)
+//
+// where maintenance is the Schedule "* 2 * * *".
+func Except(base Recurrence, exclude Schedule) Exclusion {
+	return Exclusion{base: base, exclude: exclude}
+}
+
+// Next returns the earliest occurrence of e after t.
+//
+// If base is a Schedule and every one of its occurrences is excluded, Next
+// returns the zero Time. For other kinds of base Recurrence, Next examines
+// each occurrence of base in turn, so it may take a long time if many
+// consecutive occurrences are excluded.
+func (e Exclusion) Next(t time.Time) time.Time {
+	if s, ok := e.base.(Schedule); ok && s.within(e.exclude) {
+		return time.Time{}
+	}
+	for {
+		next := e.base.Next(t)
+		if next.IsZero() || !e.exclude.Matches(next) {
+			return next
+		}
+		t = next
+	}
+}
+
+// within reports whether every time at which s fires is matched by s1.
+func (s Schedule) within(s1 Schedule) bool {
+	n, n1 := s.normalize(), s1.normalize()
+	for i := range n.b {
+		if n.b[i]&^n1.b[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// NthWeekday returns a Schedule that fires at midnight on the nth occurrence
+// of the weekday d in each month, for n from 1 to 5. For example,
+// NthWeekday(3, cron.Friday) fires on the third Friday of every month and is
+// equivalent to "0 0 15-21 * FRI". (A Schedule fires only on days matching
+// both its day of month and day of week fields.) NthWeekday(5, d) skips
+// months with only four of d. NthWeekday panics if n or d is out of range.
+func NthWeekday(n int, d time.Weekday) Schedule {
+	if n < 1 || n > 5 {
+		panic(fmt.Sprintf("cron: invalid week number %d for NthWeekday", n))
+	}
+	if d < time.Sunday || d > time.Saturday {
+		panic(fmt.Sprintf("cron: invalid weekday %d for NthWeekday", d))
+	}
+	first := 7*(n-1) + 1
+	last := first + 6
+	if last > 31 {
+		last = 31
+	}
+	var days []int
+	for day := first; day <= last; day++ {
+		days = append(days, day)
+	}
+	s, err := NewBuilder().Minutes(0).Hours(0).DaysOfMonth(days...).Weekdays(d).Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// LastWeekdayOfMonth is a Recurrence that occurs at midnight on the last
+// occurrence of a weekday in each month, such as "the last Friday of the
+// month". A cron expression can't express this because the last week of a
+// month begins on a different day of the month depending on the month's
+// length. Use LastWeekday to create a LastWeekdayOfMonth.
+type LastWeekdayOfMonth struct {
+	d time.Weekday
+}
+
+// LastWeekday returns a Recurrence that occurs at midnight on the last d of
+// each month. It panics if d is out of range.
+func LastWeekday(d time.Weekday) LastWeekdayOfMonth {
+	if d < time.Sunday || d > time.Saturday {
+		panic(fmt.Sprintf("cron: invalid weekday %d for LastWeekday", d))
+	}
+	return LastWeekdayOfMonth{d}
+}
+
+// Next returns the earliest occurrence of l after t. Occurrences are at
+// midnight in t's location.
+func (l LastWeekdayOfMonth) Next(t time.Time) time.Time {
+	year, month, _ := t.Date()
+	for {
+		// Day 0 of the following month is the last day of this month.
+		end := time.Date(year, month+1, 0, 0, 0, 0, 0, t.Location())
+		next := end.AddDate(0, 0, -((int(end.Weekday()) - int(l.d) + 7) % 7))
+		if next.After(t) {
+			return next
+		}
+		month++
+	}
+}
@@ -0,0 +1,261 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	_ Recurrence = Schedule{}
+	_ Recurrence = Once{}
+	_ Recurrence = Reboot{}
+	_ Recurrence = LastWeekdayOfMonth{}
+)
+
+func TestAt(t *testing.T) {
+	at := time.Date(2020, 3, 1, 12, 0, 0, 0, time.UTC)
+	o := At(at)
+	if got := o.Next(at.Add(-time.Hour)); !got.Equal(at) {
+		t.Errorf("Next before the occurrence: got %s; want %s", got, at)
+	}
+	if got := o.Next(at); !got.IsZero() {
+		t.Errorf("Next at the occurrence: got %s; want zero Time", got)
+	}
+	if got := o.Next(at.Add(time.Hour)); !got.IsZero() {
+		t.Errorf("Next after the occurrence: got %s; want zero Time", got)
+	}
+	loc := time.FixedZone("X", 3600)
+	if got := o.Next(at.In(loc).Add(-time.Second)); got.Location() != loc {
+		t.Errorf("Next returned a time in %s; want %s", got.Location(), loc)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const layout = "2006-01-02 15:04 MST"
+	for _, tt := range []struct {
+		iv   Interval
+		t    time.Time
+		want time.Time
+	}{
+		{
+			EveryDaysFrom(time.Date(2026, 1, 1, 9, 0, 0, 0, ny), 10),
+			time.Date(2025, 6, 1, 0, 0, 0, 0, ny),
+			time.Date(2026, 1, 1, 9, 0, 0, 0, ny),
+		},
+		{
+			EveryDaysFrom(time.Date(2026, 1, 1, 9, 0, 0, 0, ny), 10),
+			time.Date(2026, 1, 1, 9, 0, 0, 0, ny),
+			time.Date(2026, 1, 11, 9, 0, 0, 0, ny),
+		},
+		{
+			EveryDaysFrom(time.Date(2026, 1, 1, 9, 0, 0, 0, ny), 10),
+			time.Date(2026, 1, 31, 8, 59, 0, 0, ny),
+			time.Date(2026, 1, 31, 9, 0, 0, 0, ny),
+		},
+		{
+			// The wall clock time is kept across the DST change on March 8.
+			EveryDaysFrom(time.Date(2026, 1, 1, 9, 0, 0, 0, ny), 10),
+			time.Date(2026, 3, 3, 0, 0, 0, 0, ny),
+			time.Date(2026, 3, 12, 9, 0, 0, 0, ny),
+		},
+		{
+			EveryFrom(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 90*time.Minute),
+			time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC),
+			time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			EveryFrom(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 90*time.Minute),
+			time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC),
+			time.Date(2026, 1, 1, 4, 30, 0, 0, time.UTC),
+		},
+		{
+			// Elapsed time is kept across the DST change.
+			EveryFrom(time.Date(2026, 3, 7, 9, 0, 0, 0, ny), 24*time.Hour),
+			time.Date(2026, 3, 8, 0, 0, 0, 0, ny),
+			time.Date(2026, 3, 8, 10, 0, 0, 0, ny),
+		},
+		{
+			// The zero Interval has no occurrences.
+			Interval{},
+			time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Time{},
+		},
+	} {
+		got := tt.iv.Next(tt.t)
+		if !got.Equal(tt.want) {
+			t.Errorf("Next(%s) = %s; want %s", tt.t.Format(layout), got.Format(layout), tt.want.Format(layout))
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	s := mustParse(t, "0 9 * * *")
+	day := func(d, h, m int) time.Time {
+		return time.Date(2020, 5, d, h, m, 0, 0, time.UTC)
+	}
+	for _, tt := range []struct {
+		b    Bounded
+		t    time.Time
+		want time.Time
+	}{
+		{Between(s, day(10, 9, 0), day(20, 9, 0)), day(1, 0, 0), day(10, 9, 0)},
+		{Between(s, day(10, 9, 30), day(20, 9, 0)), day(1, 0, 0), day(11, 9, 0)},
+		{Between(s, day(10, 9, 0), day(20, 9, 0)), day(15, 12, 0), day(16, 9, 0)},
+		{Between(s, day(10, 9, 0), day(20, 9, 0)), day(19, 12, 0), day(20, 9, 0)},
+		{Between(s, day(10, 9, 0), day(20, 9, 0)), day(20, 9, 0), time.Time{}},
+		{Between(s, day(10, 9, 0), day(20, 8, 0)), day(19, 12, 0), time.Time{}},
+		{Between(s, time.Time{}, day(20, 9, 0)), day(1, 0, 0), day(1, 9, 0)},
+		{Between(s, day(10, 9, 0), time.Time{}), day(30, 12, 0), day(31, 9, 0)},
+		{Between(At(day(12, 0, 0)), day(10, 0, 0), day(20, 0, 0)), day(12, 0, 0), time.Time{}},
+	} {
+		if got := tt.b.Next(tt.t); !got.Equal(tt.want) {
+			t.Errorf("Next(%s) = %s; want %s", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestUnion(t *testing.T) {
+	u, err := ParseUnion("0 9 * * MON-FRI; 0 10 * * SAT\n\n30 23 * * sun\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(u) != 3 {
+		t.Fatalf("ParseUnion gave %d schedules; want 3", len(u))
+	}
+	// 2020-05-01 is a Friday.
+	tm := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	var got []time.Time
+	for i := 0; i < 4; i++ {
+		tm = u.Next(tm)
+		got = append(got, tm)
+	}
+	want := []time.Time{
+		time.Date(2020, 5, 2, 10, 0, 0, 0, time.UTC),
+		time.Date(2020, 5, 3, 23, 30, 0, 0, time.UTC),
+		time.Date(2020, 5, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2020, 5, 5, 9, 0, 0, 0, time.UTC),
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("occurrence %d: got %s; want %s", i, got[i], want[i])
+		}
+	}
+
+	once := Union{At(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))}
+	if got := once.Next(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)); !got.IsZero() {
+		t.Errorf("Next after every member has finished: got %s; want zero Time", got)
+	}
+
+	never, err := ParseUnion("0 0 30 2 *; 0 12 * * *; 0 0 31 APR *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := never.Next(tm), time.Date(2020, 5, 5, 12, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next with members that never fire: got %s; want %s", got, want)
+	}
+	if got := never[:1].Next(tm); !got.IsZero() {
+		t.Errorf("Next with no member that fires: got %s; want zero Time", got)
+	}
+
+	for _, expr := range []string{"", " ; \n", "0 9 * * *; 60 * * * *"} {
+		if _, err := ParseUnion(expr); err == nil {
+			t.Errorf("ParseUnion(%q) succeeded; want error", expr)
+		}
+	}
+}
+
+func TestExcept(t *testing.T) {
+	hourly := mustParse(t, "0 * * * *")
+	maintenance := mustParse(t, "* 2 * * *")
+	e := Except(hourly, maintenance)
+	tm := time.Date(2020, 5, 1, 0, 30, 0, 0, time.UTC)
+	var got []int
+	for i := 0; i < 4; i++ {
+		tm = e.Next(tm)
+		got = append(got, tm.Hour())
+	}
+	if diff := cmp.Diff(got, []int{1, 3, 4, 5}); diff != "" {
+		t.Errorf("hours of Except(hourly, maintenance) (-got, +want):\n%s", diff)
+	}
+
+	// Every occurrence is excluded.
+	for _, tt := range []struct {
+		base, exclude string
+	}{
+		{"0 2 * * *", "* 2 * * *"},
+		{"0 2 * 2 *", "* 2 1-29 * *"},
+		{"0 0 30 2 *", "0 1 * * *"},
+	} {
+		e := Except(mustParse(t, tt.base), mustParse(t, tt.exclude))
+		if got := e.Next(tm); !got.IsZero() {
+			t.Errorf("Except(%q, %q).Next = %s; want zero Time", tt.base, tt.exclude, got)
+		}
+	}
+	e = Except(mustParse(t, "0 2 * * *"), mustParse(t, "* 2 1-30 * *"))
+	if got, want := e.Next(tm), time.Date(2020, 5, 31, 2, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next = %s; want %s", got, want)
+	}
+}
+
+func TestNthWeekday(t *testing.T) {
+	for _, tt := range []struct {
+		n    int
+		d    time.Weekday
+		want string
+	}{
+		{1, Monday, "0 0 1-7 * MON"},
+		{3, Friday, "0 0 15-21 * FRI"},
+		{5, Sunday, "0 0 29-31 * SUN"},
+	} {
+		if got := NthWeekday(tt.n, tt.d).String(); got != tt.want {
+			t.Errorf("NthWeekday(%d, %s) = %q; want %q", tt.n, tt.d, got, tt.want)
+		}
+	}
+
+	s := NthWeekday(3, Friday)
+	tm := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var got []int
+	for i := 0; i < 3; i++ {
+		tm = s.Next(tm)
+		got = append(got, tm.Day())
+	}
+	if diff := cmp.Diff(got, []int{16, 20, 20}); diff != "" {
+		t.Errorf("days of NthWeekday(3, Friday) (-got, +want):\n%s", diff)
+	}
+}
+
+func TestLastWeekday(t *testing.T) {
+	l := LastWeekday(Friday)
+	tm := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var got []time.Time
+	for i := 0; i < 4; i++ {
+		tm = l.Next(tm)
+		got = append(got, tm)
+	}
+	want := []time.Time{
+		time.Date(2026, 1, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 27, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 4, 24, 0, 0, 0, 0, time.UTC),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("occurrences of LastWeekday(Friday) (-got, +want):\n%s", diff)
+	}
+
+	// An occurrence at exactly t is not returned.
+	tm = time.Date(2026, 7, 31, 0, 0, 0, 0, time.UTC)
+	if got, want := l.Next(tm), time.Date(2026, 8, 28, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next(%s) = %s; want %s", tm, got, want)
+	}
+	// December rolls over into the next year.
+	tm = time.Date(2026, 12, 26, 0, 0, 0, 0, time.UTC)
+	if got, want := l.Next(tm), time.Date(2027, 1, 29, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next(%s) = %s; want %s", tm, got, want)
+	}
+}
@@ -0,0 +1,301 @@
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseRRULE converts an RFC 5545 recurrence rule, such as
+// "FREQ=DAILY;BYHOUR=3;BYMINUTE=0" or "RRULE:FREQ=WEEKLY;BYDAY=MO,WE", into
+// a Schedule.
+//
+// As in iCalendar, any part of the recurrence that the rule doesn't specify
+// is taken from the rule's start time, dtstart: for example, the rule
+// "FREQ=WEEKLY" fires on dtstart's day of the week at dtstart's hour and
+// minute. Only the wall clock fields of dtstart are used. (Unlike an
+// iCalendar event, the resulting Schedule does not fire at dtstart unless
+// dtstart matches the rule.)
+//
+// Only rules that fire indefinitely at whole minutes and can be written as a
+// cron expression are supported. ParseRRULE returns an error for rules using
+// COUNT, UNTIL, BYSETPOS, BYWEEKNO, BYYEARDAY, or BYSECOND (other than
+// BYSECOND=0), for BYDAY values with an ordinal (such as -1FR) and negative
+// BYMONTHDAY values, and for an INTERVAL other than 1 except with FREQ=MINUTELY
+// or FREQ=HOURLY when the interval evenly divides an hour or a day,
+// respectively.
+func ParseRRULE(rule string, dtstart time.Time) (Schedule, error) {
+	body := rule
+	if len(body) >= 6 && strings.EqualFold(body[:6], "RRULE:") {
+		body = body[6:]
+	}
+	if body == "" {
+		return Schedule{}, errors.New("empty recurrence rule")
+	}
+	parts := make(map[string]string)
+	for _, part := range strings.Split(body, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return Schedule{}, fmt.Errorf("malformed recurrence rule part %q", part)
+		}
+		name := strings.ToUpper(kv[0])
+		if _, ok := parts[name]; ok {
+			return Schedule{}, fmt.Errorf("recurrence rule part %s appears more than once", name)
+		}
+		parts[name] = kv[1]
+	}
+	for name := range parts {
+		switch name {
+		case "FREQ", "INTERVAL", "BYMINUTE", "BYHOUR", "BYDAY", "BYMONTHDAY", "BYMONTH", "WKST":
+		case "BYSECOND":
+			if parts[name] != "0" {
+				return Schedule{}, errors.New("recurrence rules that fire at seconds other than 0 cannot be expressed as cron schedules")
+			}
+		case "COUNT", "UNTIL":
+			return Schedule{}, fmt.Errorf("recurrence rules with %s cannot be expressed as cron schedules", name)
+		case "BYSETPOS", "BYWEEKNO", "BYYEARDAY":
+			return Schedule{}, fmt.Errorf("recurrence rules with %s are not supported", name)
+		default:
+			return Schedule{}, fmt.Errorf("unknown recurrence rule part %s", name)
+		}
+	}
+
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return Schedule{}, errors.New("recurrence rule has no FREQ")
+	}
+	freq = strings.ToUpper(freq)
+	switch freq {
+	case "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+	case "SECONDLY":
+		return Schedule{}, errors.New("recurrence rules with FREQ=SECONDLY cannot be expressed as cron schedules")
+	default:
+		return Schedule{}, fmt.Errorf("invalid recurrence rule frequency %q", freq)
+	}
+	interval := 1
+	if v, ok := parts["INTERVAL"]; ok {
+		var err error
+		interval, err = strconv.Atoi(v)
+		if err != nil || interval < 1 {
+			return Schedule{}, fmt.Errorf("invalid recurrence rule interval %q", v)
+		}
+	}
+	if freq == "WEEKLY" {
+		if _, ok := parts["BYMONTHDAY"]; ok {
+			return Schedule{}, errors.New("BYMONTHDAY cannot be used with FREQ=WEEKLY")
+		}
+	}
+
+	var vals [5][]int
+	var err error
+	if v, ok := parts["BYMINUTE"]; ok {
+		if vals[0], err = parseRRULEInts(v, 0); err != nil {
+			return Schedule{}, err
+		}
+	} else if freq != "MINUTELY" {
+		vals[0] = []int{dtstart.Minute()}
+	}
+	if v, ok := parts["BYHOUR"]; ok {
+		if vals[1], err = parseRRULEInts(v, 1); err != nil {
+			return Schedule{}, err
+		}
+	} else if freq != "MINUTELY" && freq != "HOURLY" {
+		vals[1] = []int{dtstart.Hour()}
+	}
+	_, hasMonthDay := parts["BYMONTHDAY"]
+	_, hasDay := parts["BYDAY"]
+	if v, ok := parts["BYMONTHDAY"]; ok {
+		if vals[2], err = parseRRULEInts(v, 2); err != nil {
+			return Schedule{}, err
+		}
+	} else if !hasDay && (freq == "MONTHLY" || freq == "YEARLY") {
+		vals[2] = []int{dtstart.Day()}
+	}
+	if v, ok := parts["BYMONTH"]; ok {
+		if vals[3], err = parseRRULEInts(v, 3); err != nil {
+			return Schedule{}, err
+		}
+	} else if !hasMonthDay && !hasDay && freq == "YEARLY" {
+		vals[3] = []int{int(dtstart.Month())}
+	}
+	if v, ok := parts["BYDAY"]; ok {
+		if vals[4], err = parseRRULEWeekdays(v); err != nil {
+			return Schedule{}, err
+		}
+	} else if freq == "WEEKLY" {
+		vals[4] = []int{int(dtstart.Weekday())}
+	}
+
+	var s Schedule
+	for i, fieldVals := range vals {
+		if fieldVals == nil {
+			for j := 0; j < fieldSizes[i]; j++ {
+				s = s.set(fieldOffsets[i] + j)
+			}
+			continue
+		}
+		for _, v := range fieldVals {
+			s = s.set(fieldOffsets[i] + v - fieldMin(i))
+		}
+	}
+
+	if interval > 1 {
+		// The interval keeps every interval'th minute or hour counting
+		// from dtstart. This can be expressed as a step in the
+		// corresponding field only if the interval evenly divides the
+		// next larger unit.
+		var fieldIndex, start int
+		switch freq {
+		case "MINUTELY":
+			fieldIndex, start = 0, dtstart.Minute()
+		case "HOURLY":
+			fieldIndex, start = 1, dtstart.Hour()
+		default:
+			return Schedule{}, fmt.Errorf("recurrence rules with FREQ=%s and INTERVAL=%d cannot be expressed as cron schedules", freq, interval)
+		}
+		if fieldSizes[fieldIndex]%interval != 0 {
+			return Schedule{}, fmt.Errorf("recurrence rules with FREQ=%s and INTERVAL=%d cannot be expressed as cron schedules", freq, interval)
+		}
+		for j := 0; j < fieldSizes[fieldIndex]; j++ {
+			if (j-start)%interval != 0 {
+				s = s.unset(fieldOffsets[fieldIndex] + j)
+			}
+		}
+	}
+	// Checking that every field has a value is not enough: a rule may name
+	// only days which no month has, such as February 30.
+	if s.normalize() == (Schedule{}) {
+		return Schedule{}, fmt.Errorf("recurrence rule %q never fires", rule)
+	}
+	return s, nil
+}
+
+var icalWeekdays = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// ToRRULE converts s into an equivalent RFC 5545 recurrence rule, such as
+// "FREQ=WEEKLY;BYDAY=WE;BYHOUR=3;BYMINUTE=0". The result does not include
+// the "RRULE:" property name.
+//
+// The rule specifies every part of the recurrence, so it does not depend on
+// the start time (DTSTART) of the iCalendar component that contains it.
+// However, iCalendar always treats DTSTART as the first occurrence, so
+// DTSTART should be set to a time at which s fires.
+//
+// ToRRULE returns an error if s is invalid or never fires.
+func ToRRULE(s Schedule) (string, error) {
+	if err := s.Validate(); err != nil {
+		return "", fmt.Errorf("cannot convert invalid cron schedule to a recurrence rule: %w", err)
+	}
+	if s.normalize() == (Schedule{}) {
+		return "", fmt.Errorf("cron schedule %q never fires", s)
+	}
+	return s.rrule(), nil
+}
+
+// rrule returns a recurrence rule for s, as described by ToRRULE.
+func (s Schedule) rrule() string {
+	mins, allMins := s.Minutes()
+	hrs, allHours := s.Hours()
+	domVals, allDoms := s.DaysOfMonth()
+	monthVals, allMonths := s.Months()
+	dowVals, allDows := s.Weekdays()
+
+	// Choose the coarsest frequency for which every BYxxx part either
+	// expands to exactly the schedule's values or limits the occurrences
+	// to them.
+	var freq string
+	switch {
+	case allMins:
+		freq = "MINUTELY"
+	case allHours:
+		freq = "HOURLY"
+	case allDoms && allDows:
+		freq = "DAILY"
+	case allDoms && allMonths:
+		freq = "WEEKLY"
+	case allMonths:
+		freq = "MONTHLY"
+	default:
+		freq = "DAILY"
+	}
+	parts := []string{"FREQ=" + freq}
+	if !allMonths {
+		parts = append(parts, "BYMONTH="+joinInts(monthVals))
+	}
+	if !allDoms {
+		parts = append(parts, "BYMONTHDAY="+joinInts(domVals))
+	}
+	if !allDows {
+		days := make([]string, len(dowVals))
+		for i, v := range dowVals {
+			days[i] = icalWeekdays[v]
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if !allHours {
+		parts = append(parts, "BYHOUR="+joinInts(hrs))
+	}
+	if !allMins {
+		parts = append(parts, "BYMINUTE="+joinInts(mins))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(vals []int) string {
+	strs := make([]string, len(vals))
+	for i, v := range vals {
+		strs[i] = fmt.Sprint(v)
+	}
+	return strings.Join(strs, ",")
+}
+
+var rruleFieldNames = [...]string{
+	0: "BYMINUTE",
+	1: "BYHOUR",
+	2: "BYMONTHDAY",
+	3: "BYMONTH",
+}
+
+func parseRRULEInts(list string, fieldIndex int) ([]int, error) {
+	var vals []int
+	for _, v := range strings.Split(list, ",") {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value %q", rruleFieldNames[fieldIndex], v)
+		}
+		if fieldIndex == 2 && n < 0 {
+			return nil, fmt.Errorf("negative %s values (counting from the end of the month) are not supported", rruleFieldNames[fieldIndex])
+		}
+		min := fieldMin(fieldIndex)
+		if n < min || n >= min+fieldSizes[fieldIndex] {
+			return nil, fmt.Errorf("invalid %s value %d", rruleFieldNames[fieldIndex], n)
+		}
+		vals = append(vals, n)
+	}
+	return vals, nil
+}
+
+func parseRRULEWeekdays(list string) ([]int, error) {
+	var vals []int
+outer:
+	for _, v := range strings.Split(list, ",") {
+		day := strings.ToUpper(v)
+		for i, name := range icalWeekdays {
+			if day == name {
+				vals = append(vals, i)
+				continue outer
+			}
+		}
+		if len(day) > 2 {
+			for _, name := range icalWeekdays {
+				if strings.HasSuffix(day, name) {
+					return nil, fmt.Errorf("BYDAY values with an ordinal (%q) are not supported", v)
+				}
+			}
+		}
+		return nil, fmt.Errorf("invalid BYDAY value %q", v)
+	}
+	return vals, nil
+}
@@ -0,0 +1,130 @@
+package cron
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseRRULE(t *testing.T) {
+	// A Wednesday.
+	dtstart := time.Date(2020, 4, 15, 9, 30, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		rule string
+		want testSchedule
+	}{
+		{"FREQ=DAILY;BYHOUR=3;BYMINUTE=0", testSchedule{{0}, {3}, nil, nil, nil}},
+		{"RRULE:FREQ=WEEKLY;BYDAY=MO,WE", testSchedule{{30}, {9}, nil, nil, {1, 3}}},
+		{"freq=weekly", testSchedule{{30}, {9}, nil, nil, {3}}},
+		{"FREQ=MINUTELY", testSchedule{nil, nil, nil, nil, nil}},
+		{"FREQ=MINUTELY;INTERVAL=15", testSchedule{{0, 15, 30, 45}, nil, nil, nil, nil}},
+		{"FREQ=MINUTELY;BYHOUR=9,10,11;BYDAY=MO,TU,WE,TH,FR", testSchedule{nil, {9, 10, 11}, nil, nil, {1, 2, 3, 4, 5}}},
+		{"FREQ=HOURLY", testSchedule{{30}, nil, nil, nil, nil}},
+		{"FREQ=HOURLY;INTERVAL=6;BYMINUTE=0", testSchedule{{0}, {3, 9, 15, 21}, nil, nil, nil}},
+		{"FREQ=DAILY", testSchedule{{30}, {9}, nil, nil, nil}},
+		{"FREQ=DAILY;BYMONTH=1,7", testSchedule{{30}, {9}, nil, {1, 7}, nil}},
+		{"FREQ=MONTHLY", testSchedule{{30}, {9}, {15}, nil, nil}},
+		{"FREQ=MONTHLY;BYMONTHDAY=1,15", testSchedule{{30}, {9}, {1, 15}, nil, nil}},
+		{"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13", testSchedule{{30}, {9}, {13}, nil, {5}}},
+		{"FREQ=MONTHLY;BYDAY=SA,SU", testSchedule{{30}, {9}, nil, nil, {0, 6}}},
+		{"FREQ=YEARLY", testSchedule{{30}, {9}, {15}, {4}, nil}},
+		{"FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=0;BYMINUTE=0", testSchedule{{0}, {0}, {25}, {12}, nil}},
+		{"FREQ=YEARLY;BYMONTHDAY=1", testSchedule{{30}, {9}, {1}, nil, nil}},
+		{"FREQ=YEARLY;BYMONTH=3;BYDAY=SU", testSchedule{{30}, {9}, nil, {3}, {0}}},
+		{"FREQ=DAILY;BYSECOND=0;WKST=MO", testSchedule{{30}, {9}, nil, nil, nil}},
+	} {
+		s, err := ParseRRULE(tt.rule, dtstart)
+		if err != nil {
+			t.Errorf("ParseRRULE(%q): %s", tt.rule, err)
+			continue
+		}
+		if diff := cmp.Diff(toTestSchedule(s), tt.want); diff != "" {
+			t.Errorf("ParseRRULE(%q): (-got, +want):\n%s", tt.rule, diff)
+		}
+	}
+}
+
+func TestParseRRULEFail(t *testing.T) {
+	dtstart := time.Date(2020, 4, 15, 9, 30, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		rule string
+		want string // substring
+	}{
+		{"", "empty"},
+		{"BYHOUR=3", "no FREQ"},
+		{"FREQ=SECONDLY", "SECONDLY"},
+		{"FREQ=FORTNIGHTLY", "invalid recurrence rule frequency"},
+		{"FREQ=DAILY;COUNT=10", "COUNT"},
+		{"FREQ=DAILY;UNTIL=20201231T000000Z", "UNTIL"},
+		{"FREQ=MONTHLY;BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR", "BYSETPOS"},
+		{"FREQ=DAILY;BYSECOND=30", "seconds"},
+		{"FREQ=DAILY;INTERVAL=2", "INTERVAL=2"},
+		{"FREQ=MINUTELY;INTERVAL=7", "INTERVAL=7"},
+		{"FREQ=DAILY;INTERVAL=0", "invalid recurrence rule interval"},
+		{"FREQ=MONTHLY;BYDAY=-1FR", "ordinal"},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", "negative"},
+		{"FREQ=DAILY;BYHOUR=24", "invalid BYHOUR value 24"},
+		{"FREQ=DAILY;BYDAY=XX", "invalid BYDAY value"},
+		{"FREQ=WEEKLY;BYMONTHDAY=1", "BYMONTHDAY cannot be used"},
+		{"FREQ=DAILY;FREQ=WEEKLY", "more than once"},
+		{"FREQ=DAILY;FOO=BAR", "unknown recurrence rule part"},
+		{"FREQ=DAILY;BYHOUR", "malformed"},
+		{"FREQ=MINUTELY;INTERVAL=15;BYMINUTE=1,2", "never fires"},
+		{"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", "never fires"},
+		{"FREQ=MONTHLY;BYMONTH=4,6;BYMONTHDAY=31", "never fires"},
+	} {
+		_, err := ParseRRULE(tt.rule, dtstart)
+		if err == nil {
+			t.Errorf("ParseRRULE accepted %q, but it is invalid", tt.rule)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseRRULE(%q): got error %q; want substring %q", tt.rule, err, tt.want)
+		}
+	}
+}
+
+func TestToRRULE(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "FREQ=MINUTELY"},
+		{"* 9-11 * * MON-FRI", "FREQ=MINUTELY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9,10,11"},
+		{"*/15 * * * *", "FREQ=HOURLY;BYMINUTE=0,15,30,45"},
+		{"0 3 * * *", "FREQ=DAILY;BYHOUR=3;BYMINUTE=0"},
+		{"0 3 * 1,7 *", "FREQ=DAILY;BYMONTH=1,7;BYHOUR=3;BYMINUTE=0"},
+		{"0 3 * * Wed", "FREQ=WEEKLY;BYDAY=WE;BYHOUR=3;BYMINUTE=0"},
+		{"0 0 1,15 * *", "FREQ=MONTHLY;BYMONTHDAY=1,15;BYHOUR=0;BYMINUTE=0"},
+		{"0 12 13 * Fri", "FREQ=MONTHLY;BYMONTHDAY=13;BYDAY=FR;BYHOUR=12;BYMINUTE=0"},
+		{"0 0 25 12 *", "FREQ=DAILY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=0;BYMINUTE=0"},
+		{"0 0 * 3 Sun", "FREQ=DAILY;BYMONTH=3;BYDAY=SU;BYHOUR=0;BYMINUTE=0"},
+	} {
+		s := mustParse(t, tt.expr)
+		got, err := ToRRULE(s)
+		if err != nil {
+			t.Errorf("ToRRULE(%q): %s", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ToRRULE(%q) = %q; want %q", tt.expr, got, tt.want)
+		}
+		// The rule doesn't depend on dtstart.
+		s1, err := ParseRRULE(got, time.Date(2001, 2, 3, 4, 5, 0, 0, time.UTC))
+		if err != nil {
+			t.Errorf("ParseRRULE(%q): %s", got, err)
+			continue
+		}
+		if s1 != s {
+			t.Errorf("ParseRRULE(ToRRULE(%q)) = %q", tt.expr, s1)
+		}
+	}
+	if _, err := ToRRULE(Schedule{}); err == nil {
+		t.Error("ToRRULE accepted an invalid schedule")
+	}
+	if _, err := ToRRULE(mustParse(t, "0 0 30 2 *")); err == nil {
+		t.Error("ToRRULE accepted a schedule that never fires")
+	}
+}
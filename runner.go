@@ -0,0 +1,1068 @@
+package cron
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A Runner runs jobs according to their Recurrences. Create a Runner with
+// NewRunner, add jobs with Add or AddFunc, and call Start to begin running
+// them:
+//
+//	r := cron.NewRunner()
+//	r.AddFunc(nightly, rebuildIndex)
+//	r.Start()
+//	defer r.Stop()
+//
+// Alternatively, Run runs the jobs in the calling goroutine until a context
+// is canceled. Shutdown stops the Runner and waits for running jobs to
+// finish, canceling their contexts if they take too long.
+//
+// Each job runs in its own goroutine at each occurrence of its Recurrence.
+// If the Runner falls behind (for example, because the system was
+// suspended), a job's missed occurrences are skipped and it runs at its
+// next occurrence instead, unless its CatchUp policy says otherwise.
+//
+// A Runner is safe for concurrent use.
+type Runner struct {
+	mu      sync.Mutex
+	entries []*runnerEntry // in order of ID
+	lastID  JobID
+	running bool
+	started bool          // whether r has ever started, for Reboot jobs
+	wake    chan struct{} // signals the run loop that entries changed
+	stop    chan struct{}
+	done    chan struct{}
+
+	// The following describe the jobs in progress. They are reset when
+	// active drops to zero.
+	active     int                // number of jobs running
+	drained    chan struct{}      // closed when active drops to zero; nil if active is zero
+	jobCtx     context.Context    // parent of the jobs' contexts
+	cancelJobs context.CancelFunc // cancels jobCtx
+
+	mw      []Middleware
+	logger  Logger  // may be nil
+	logq    []Event // events waiting to be logged outside mu
+	tracer  Tracer  // may be nil
+	clock   Clock
+	store   Store                    // may be nil
+	locker  Locker                   // may be nil
+	onError func(JobID, error)       // may be nil
+	onPanic func(JobID, *PanicError) // nil if panics are not recovered
+	sem     *semaphore               // limits concurrent runs; nil if unlimited
+	errs    []JobError               // the most recent errors, oldest first
+}
+
+type runnerEntry struct {
+	id      JobID
+	name    string
+	r       Recurrence
+	job     Job
+	overlap Overlap
+	catchUp CatchUp
+	timeout time.Duration
+	mw      []Middleware
+	prev    time.Time // zero if the job has not run
+	next    time.Time // zero if the Runner is stopped or r has no occurrences left
+	active  int       // number of runs in progress
+	pending time.Time // with OverlapDelay, an occurrence waiting for active to reach zero
+	skip    time.Time // occurrences before this are skipped
+	paused  bool
+	after   []JobID // jobs whose successful runs trigger this one
+	prio    int
+
+	jitter     time.Duration // maximum delay of each run
+	seeded     bool          // whether the delay is fixed
+	seedJitter time.Duration // the fixed delay, if seeded
+	delay      time.Duration // delay of the run for next
+}
+
+// A Job is a function run by a Runner. The context is canceled if the job
+// runs longer than its timeout (see WithTimeout), or if it is still running
+// when Shutdown gives up waiting for it. Jobs should return promptly once
+// their context is canceled.
+type Job func(ctx context.Context) error
+
+// A Middleware wraps a Job in another Job, typically one which does some
+// work before or after calling the original. Middleware lets concerns such
+// as logging, metrics, and locking apply to many jobs.
+type Middleware func(Job) Job
+
+// A JobOption configures a job added to a Runner.
+type JobOption func(*runnerEntry)
+
+// WithName gives the job a name, for identifying it in JobInfo and Events.
+func WithName(name string) JobOption {
+	return func(e *runnerEntry) { e.name = name }
+}
+
+// WithJitter delays each run of the job by a random duration less than
+// max, to spread out the runs of jobs with the same schedule. The delay
+// does not change the occurrence reported for the run, such as in
+// JobInfo.Prev and in Events.
+func WithJitter(max time.Duration) JobOption {
+	return func(e *runnerEntry) {
+		e.jitter = max
+		e.seeded = false
+	}
+}
+
+// WithSeededJitter is like WithJitter, but delays every run of the job by
+// the same duration, chosen using the given seed. Given the same seed, the
+// same delay is chosen each time, so jobs keep their delays across
+// restarts. The seed is typically given by KeySeed, as for ParseH.
+func WithSeededJitter(max time.Duration, seed uint64) JobOption {
+	return func(e *runnerEntry) {
+		e.jitter = max
+		e.seeded = true
+		if max > 0 {
+			e.seedJitter = time.Duration(rand.New(rand.NewSource(int64(seed))).Int63n(int64(max)))
+		}
+	}
+}
+
+// After makes the job run each time the job with the given ID finishes a
+// run successfully, that is, without an error or panic, in addition to its
+// own Recurrence. The job runs for the same occurrence as the job it
+// follows, subject to its own Overlap policy. Jobs are not triggered this
+// way while the Runner is stopped. If there is no job with the given ID
+// when the job is added, the option has no effect, so that, since IDs
+// increase, there can be no cycles of jobs.
+func After(id JobID) JobOption {
+	return func(e *runnerEntry) { e.after = append(e.after, id) }
+}
+
+// WithPriority sets the job's priority, which is zero by default. When
+// several jobs are due at the same time, the Runner starts those with
+// higher priorities first. This matters most with WithMaxConcurrency, when
+// the jobs with lower priorities may have to wait.
+func WithPriority(p int) JobOption {
+	return func(e *runnerEntry) { e.prio = p }
+}
+
+// WithTimeout sets a timeout for each run of the job, after which its
+// context is canceled. A non-positive d means no timeout, the default.
+func WithTimeout(d time.Duration) JobOption {
+	return func(e *runnerEntry) { e.timeout = d }
+}
+
+// An Overlap is a policy for what a Runner does when a job is due to run
+// while a previous run of it is still in progress.
+type Overlap int
+
+const (
+	// OverlapAllow starts the new run alongside those in progress. It is
+	// the default.
+	OverlapAllow Overlap = iota
+	// OverlapSkip skips the new run.
+	OverlapSkip
+	// OverlapDelay delays the new run until the run in progress finishes.
+	// If several runs are delayed, only one of them happens.
+	OverlapDelay
+)
+
+// WithJobMiddleware wraps the job in the given Middleware. The first
+// Middleware is the outermost. Middleware given to the Runner with
+// WithMiddleware wraps the job's own Middleware.
+func WithJobMiddleware(mw ...Middleware) JobOption {
+	return func(e *runnerEntry) { e.mw = append(e.mw, mw...) }
+}
+
+// A CatchUp is a policy for what a Runner does about occurrences of a job
+// which it missed, either because it was not running (for example, because
+// the program was restarted; see WithStore) or because it fell behind.
+type CatchUp int
+
+const (
+	// CatchUpSkip skips the missed occurrences. It is the default.
+	CatchUpSkip CatchUp = iota
+	// CatchUpOnce runs the job once, for the first missed occurrence, as
+	// the Runner starts.
+	CatchUpOnce
+	// CatchUpAll runs the job for each missed occurrence in turn.
+	CatchUpAll
+)
+
+// WithCatchUp sets the job's CatchUp policy.
+func WithCatchUp(c CatchUp) JobOption {
+	return func(e *runnerEntry) { e.catchUp = c }
+}
+
+// WithOverlap sets the job's Overlap policy.
+func WithOverlap(o Overlap) JobOption {
+	return func(e *runnerEntry) { e.overlap = o }
+}
+
+// A JobID identifies a job added to a Runner. IDs are never reused within a
+// Runner, and no job has the zero JobID.
+type JobID uint64
+
+// JobInfo describes a job added to a Runner.
+type JobInfo struct {
+	ID         JobID
+	Name       string
+	Recurrence Recurrence
+	// Prev is the occurrence at which the job last ran, or the zero Time if
+	// it has not run.
+	Prev time.Time
+	// Next is the occurrence at which the job will next run, or the zero
+	// Time if the Runner is not running or the Recurrence has no more
+	// occurrences.
+	Next time.Time
+	// Active is the number of runs of the job in progress.
+	Active int
+	// SkipUntil is the time before which the job's occurrences are skipped
+	// (see SkipUntil), or the zero Time.
+	SkipUntil time.Time
+	// Paused is whether the job is paused (see Pause).
+	Paused bool
+}
+
+// NewRunner returns a Runner with no jobs, configured by the given options.
+func NewRunner(opts ...RunnerOption) *Runner {
+	r := &Runner{wake: make(chan struct{}, 1), clock: SystemClock}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// A RunnerOption configures a Runner created by NewRunner.
+type RunnerOption func(*Runner)
+
+// WithMiddleware wraps every job added to the Runner in the given
+// Middleware. The first Middleware is the outermost.
+func WithMiddleware(mw ...Middleware) RunnerOption {
+	return func(r *Runner) { r.mw = append(r.mw, mw...) }
+}
+
+// WithLogger makes the Runner report what it does to l.
+func WithLogger(l Logger) RunnerOption {
+	return func(r *Runner) { r.logger = l }
+}
+
+// WithErrorHandler makes the Runner call h with the ID of each job that
+// returns a non-nil error, and the error. Without this option, errors
+// returned by jobs are ignored.
+func WithErrorHandler(h func(JobID, error)) RunnerOption {
+	return func(r *Runner) { r.onError = h }
+}
+
+// WithClock makes the Runner use c, rather than SystemClock, to decide when
+// to run jobs and to timestamp Events. Job timeouts (see WithTimeout) are
+// measured in real time regardless.
+func WithClock(c Clock) RunnerOption {
+	return func(r *Runner) { r.clock = c }
+}
+
+// WithPanicHandler makes the Runner recover from panics in jobs. After a
+// job panics, the Runner calls h, if it is not nil, with the job's ID and
+// the recovered value, and continues to run the job at its later
+// occurrences as usual.
+//
+// Without this option, a panic in a job crashes the program, as a panic in
+// any goroutine does.
+func WithPanicHandler(h func(JobID, *PanicError)) RunnerOption {
+	return func(r *Runner) {
+		if h == nil {
+			h = func(JobID, *PanicError) {}
+		}
+		r.onPanic = h
+	}
+}
+
+// WithMaxConcurrency limits the number of jobs the Runner runs at once to
+// n. A job which is due while n jobs are running waits for one of them to
+// finish. Waiting jobs run in order of priority (see WithPriority), and
+// then in the order they became due. If the Runner is stopped in the
+// meantime, the waiting job does not run. WithMaxConcurrency panics if n < 1.
+func WithMaxConcurrency(n int) RunnerOption {
+	if n < 1 {
+		panic("cron: non-positive limit for WithMaxConcurrency")
+	}
+	return func(r *Runner) { r.sem = newSemaphore(n) }
+}
+
+// A PanicError records a panic recovered from a job.
+type PanicError struct {
+	Value interface{} // the value passed to panic
+	Stack []byte      // the stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job panicked: %v", e.Value)
+}
+
+// Add adds a job which runs at each occurrence of rec, configured by the
+// given options, and returns its ID. Jobs may be added while the Runner is
+// running. If rec is nil, the job runs only when triggered by RunNow or by
+// another job (see After).
+func (r *Runner) Add(rec Recurrence, job Job, opts ...JobOption) JobID {
+	e := &runnerEntry{r: rec, job: job}
+	for _, opt := range opts {
+		opt(e)
+	}
+	e.job = wrap(wrap(job, e.mw), r.mw)
+	var loadErr error
+	if r.store != nil && e.name != "" {
+		e.prev, loadErr = r.store.LoadLastRun(e.name)
+	}
+	r.mu.Lock()
+	after := e.after[:0]
+	for _, id := range e.after {
+		if r.find(id) >= 0 {
+			after = append(after, id)
+		}
+	}
+	e.after = after
+	r.lastID++
+	e.id = r.lastID
+	if r.running {
+		e.setNext(e.first(r.clock.Now()))
+		r.logScheduled(e)
+	}
+	r.entries = append(r.entries, e)
+	r.mu.Unlock()
+	r.flushLog()
+	r.notify()
+	if loadErr != nil {
+		r.handleError(e.id, fmt.Errorf("loading last run of %s: %s", e.name, loadErr))
+	}
+	return e.id
+}
+
+// wrap wraps job in mw, with mw[0] outermost.
+func wrap(job Job, mw []Middleware) Job {
+	for i := len(mw) - 1; i >= 0; i-- {
+		job = mw[i](job)
+	}
+	return job
+}
+
+// AddFunc is like Add, but for a job which calls fn and ignores its context.
+func (r *Runner) AddFunc(rec Recurrence, fn func(), opts ...JobOption) JobID {
+	return r.Add(rec, func(context.Context) error {
+		fn()
+		return nil
+	}, opts...)
+}
+
+// Remove removes the job with the given ID so that it does not run again.
+// It does not stop a run of the job already in progress. Remove reports
+// whether there was such a job.
+func (r *Runner) Remove(id JobID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(id)
+	if i < 0 {
+		return false
+	}
+	r.entries[i].pending = time.Time{}
+	copy(r.entries[i:], r.entries[i+1:])
+	r.entries[len(r.entries)-1] = nil
+	r.entries = r.entries[:len(r.entries)-1]
+	return true
+}
+
+// Entries describes the Runner's jobs, in the order they were added.
+func (r *Runner) Entries() []JobInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	infos := make([]JobInfo, len(r.entries))
+	for i, e := range r.entries {
+		infos[i] = e.info()
+	}
+	return infos
+}
+
+// setNext sets the next occurrence of e to t, choosing its jitter.
+func (e *runnerEntry) setNext(t time.Time) {
+	e.next = t
+	switch {
+	case e.jitter <= 0:
+		e.delay = 0
+	case e.seeded:
+		e.delay = e.seedJitter
+	default:
+		e.delay = time.Duration(rand.Int63n(int64(e.jitter)))
+	}
+}
+
+// due returns the time at which e is due to run for its next occurrence,
+// or the zero Time if it has none.
+func (e *runnerEntry) due() time.Time {
+	if e.next.IsZero() {
+		return time.Time{}
+	}
+	return e.next.Add(e.delay)
+}
+
+// first returns the occurrence at which e should first run when the Runner
+// starts at now: the first occurrence after e last ran, if that was missed
+// and e catches up, or otherwise the first occurrence after now.
+func (e *runnerEntry) first(now time.Time) time.Time {
+	if e.catchUp != CatchUpSkip && !e.prev.IsZero() {
+		if missed := e.nextAfter(e.prev); !missed.IsZero() && !missed.After(now) {
+			return missed
+		}
+	}
+	return e.nextAfter(now)
+}
+
+// nextAfter returns the first occurrence of e's Recurrence after t, or the
+// zero Time if there is none or e has no Recurrence.
+func (e *runnerEntry) nextAfter(t time.Time) time.Time {
+	if e.r == nil {
+		return time.Time{}
+	}
+	return e.r.Next(t)
+}
+
+func (e *runnerEntry) info() JobInfo {
+	return JobInfo{
+		ID:         e.id,
+		Name:       e.name,
+		Recurrence: e.r,
+		Prev:       e.prev,
+		Next:       e.next,
+		Active:     e.active,
+		SkipUntil:  e.skip,
+		Paused:     e.paused,
+	}
+}
+
+// RunNow runs the job with the given ID immediately, subject to its
+// Overlap policy, as if it had an occurrence at the current time. This does
+// not change when the job runs next. RunNow works whether or not the Runner
+// is running. It reports whether there is such a job.
+func (r *Runner) RunNow(id JobID) bool {
+	r.mu.Lock()
+	i := r.find(id)
+	if i >= 0 {
+		r.trigger(r.entries[i], r.clock.Now())
+	}
+	r.mu.Unlock()
+	r.flushLog()
+	return i >= 0
+}
+
+// SkipUntil makes the job with the given ID skip its occurrences before t,
+// replacing any earlier call to SkipUntil or SkipNext for the job. It does
+// not affect RunNow. SkipUntil reports whether there is such a job.
+func (r *Runner) SkipUntil(id JobID, t time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(id)
+	if i < 0 {
+		return false
+	}
+	r.entries[i].skip = t
+	return true
+}
+
+// SkipNext makes the job with the given ID skip its next occurrence, as by
+// SkipUntil, and returns that occurrence. If the Runner is not running, the
+// next occurrence is the first after the current time. SkipNext reports
+// false if there is no such job or it has no next occurrence.
+func (r *Runner) SkipNext(id JobID) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(id)
+	if i < 0 {
+		return time.Time{}, false
+	}
+	e := r.entries[i]
+	next := e.next
+	if !r.running {
+		next = e.nextAfter(r.clock.Now())
+	}
+	if next.IsZero() {
+		return time.Time{}, false
+	}
+	e.skip = next.Add(time.Nanosecond)
+	return next, true
+}
+
+// Pause makes the job with the given ID skip its occurrences, and its runs
+// triggered by other jobs (see After), until Resume is called. Like
+// SkipUntil, it does not affect RunNow. Pause reports whether there is such
+// a job.
+func (r *Runner) Pause(id JobID) bool {
+	return r.setPaused(id, true)
+}
+
+// Resume undoes Pause for the job with the given ID, so that it runs at its
+// next occurrence. It reports whether there is such a job.
+func (r *Runner) Resume(id JobID) bool {
+	return r.setPaused(id, false)
+}
+
+func (r *Runner) setPaused(id JobID, paused bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.find(id)
+	if i < 0 {
+		return false
+	}
+	r.entries[i].paused = paused
+	return true
+}
+
+// find returns the index of the entry with the given ID, or -1 if there is
+// none. The caller must hold r.mu.
+func (r *Runner) find(id JobID) int {
+	i := sort.Search(len(r.entries), func(i int) bool { return r.entries[i].id >= id })
+	if i < len(r.entries) && r.entries[i].id == id {
+		return i
+	}
+	return -1
+}
+
+// Start begins running jobs in a new goroutine. It does nothing if the
+// Runner is already running.
+func (r *Runner) Start() {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return
+	}
+	stop, done := r.start()
+	r.mu.Unlock()
+	r.flushLog()
+	go r.run(stop, done)
+}
+
+// Run runs jobs until ctx is done or the Runner is stopped by Stop or
+// Shutdown. If ctx is done, Run stops the Runner and returns ctx.Err();
+// otherwise it returns nil. Like Stop, Run does not wait for jobs that are
+// already running; use Shutdown for that. Run returns an error immediately
+// if the Runner is already running.
+func (r *Runner) Run(ctx context.Context) error {
+	r.mu.Lock()
+	if r.running {
+		r.mu.Unlock()
+		return errors.New("Runner is already running")
+	}
+	stop, done := r.start()
+	r.mu.Unlock()
+	r.flushLog()
+	go r.run(stop, done)
+	select {
+	case <-ctx.Done():
+		r.Stop()
+		return ctx.Err()
+	case <-done:
+		return nil
+	}
+}
+
+// start marks r as running and computes the next occurrence of each job.
+// The first time, it also runs the Reboot jobs. It returns the channels
+// for a new run loop. The caller must hold r.mu.
+func (r *Runner) start() (stop, done chan struct{}) {
+	r.running = true
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	now := r.clock.Now()
+	for _, e := range r.entries {
+		e.setNext(e.first(now))
+		r.logScheduled(e)
+	}
+	if !r.started {
+		r.started = true
+		for _, e := range r.entries {
+			if _, ok := e.r.(Reboot); ok {
+				r.fire(e, now)
+			}
+		}
+	}
+	return r.stop, r.done
+}
+
+// Stop stops the Runner from starting jobs. It does not wait for jobs that
+// are already running. It does nothing if the Runner is not running.
+// A stopped Runner may be started again.
+func (r *Runner) Stop() {
+	r.mu.Lock()
+	if !r.running {
+		r.mu.Unlock()
+		return
+	}
+	r.running = false
+	for _, e := range r.entries {
+		e.next = time.Time{}
+		e.pending = time.Time{}
+	}
+	stop, done := r.stop, r.done
+	r.mu.Unlock()
+	close(stop)
+	<-done
+}
+
+// Shutdown stops the Runner, as by Stop, and then waits for running jobs to
+// finish. If ctx is done first, Shutdown cancels the contexts of the running
+// jobs and returns ctx.Err() without waiting further. A Runner that is not
+// running may still have jobs running, so Shutdown waits for them even then.
+func (r *Runner) Shutdown(ctx context.Context) error {
+	r.Stop()
+	return r.wait(ctx, true)
+}
+
+// Wait waits until no jobs are running. If ctx is done first, Wait returns
+// ctx.Err(). Unlike Shutdown, Wait does not stop the Runner, so if it is
+// running, more jobs may start as soon as Wait returns.
+func (r *Runner) Wait(ctx context.Context) error {
+	return r.wait(ctx, false)
+}
+
+func (r *Runner) wait(ctx context.Context, cancel bool) error {
+	r.mu.Lock()
+	drained, cancelJobs := r.drained, r.cancelJobs
+	r.mu.Unlock()
+	if drained == nil {
+		return nil
+	}
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		if cancel {
+			cancelJobs()
+		}
+		return ctx.Err()
+	}
+}
+
+// notify wakes the run loop so that it reconsiders the entries.
+func (r *Runner) notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Runner) run(stop, done chan struct{}) {
+	defer close(done)
+	for {
+		var timer Timer
+		var fire <-chan time.Time
+		if next := r.earliest(); !next.IsZero() {
+			// As in Schedule.Wait, check the clock at least every
+			// maxWaitTimer, rather than trusting a long timer, which
+			// fires late if the computer sleeps or the wall clock is
+			// changed.
+			d := next.Sub(r.clock.Now())
+			if d > maxWaitTimer {
+				d = maxWaitTimer
+			}
+			timer = r.clock.NewTimer(d)
+			fire = timer.C()
+		}
+		select {
+		case <-fire:
+			r.runDue(r.clock.Now())
+		case <-r.wake:
+		case <-stop:
+		}
+		// The crontest package relies on Stop being called after a fired
+		// timer is handled.
+		if timer != nil {
+			timer.Stop()
+		}
+		select {
+		case <-stop:
+			return
+		default:
+		}
+	}
+}
+
+// earliest returns the earliest time at which any job is due, or the zero
+// Time if there is none.
+func (r *Runner) earliest() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var earliest time.Time
+	for _, e := range r.entries {
+		if due := e.due(); !due.IsZero() && (earliest.IsZero() || due.Before(earliest)) {
+			earliest = due
+		}
+	}
+	return earliest
+}
+
+// runDue starts the jobs that are due at now, in order of priority.
+func (r *Runner) runDue(now time.Time) {
+	r.mu.Lock()
+	var due []*runnerEntry
+	for _, e := range r.entries {
+		if t := e.due(); !t.IsZero() && !t.After(now) {
+			due = append(due, e)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool { return due[i].prio > due[j].prio })
+	for _, e := range due {
+		r.fire(e, e.next)
+		if e.catchUp == CatchUpAll {
+			e.setNext(e.nextAfter(e.next))
+		} else {
+			e.setNext(e.nextAfter(now))
+		}
+		r.logScheduled(e)
+	}
+	r.mu.Unlock()
+	r.flushLog()
+}
+
+// fire runs the job of e for its occurrence at t, unless the occurrence is
+// skipped. The caller must hold r.mu.
+func (r *Runner) fire(e *runnerEntry, t time.Time) {
+	if e.paused || t.Before(e.skip) {
+		r.queueLog(Event{Kind: EventSkipped, ID: e.id, Name: e.name, Occurrence: t})
+		return
+	}
+	r.trigger(e, t)
+}
+
+// trigger runs the job of e for the occurrence at t, subject to its
+// Overlap policy. The caller must hold r.mu.
+func (r *Runner) trigger(e *runnerEntry, t time.Time) {
+	switch {
+	case e.active == 0 || e.overlap == OverlapAllow:
+		r.launch(e, t)
+	case e.overlap == OverlapDelay:
+		r.queueLog(Event{Kind: EventDelayed, ID: e.id, Name: e.name, Occurrence: t})
+		e.pending = t
+	default:
+		r.queueLog(Event{Kind: EventSkipped, ID: e.id, Name: e.name, Occurrence: t})
+	}
+}
+
+// logScheduled queues an EventScheduled for e's next occurrence. The caller
+// must hold r.mu.
+func (r *Runner) logScheduled(e *runnerEntry) {
+	r.queueLog(Event{Kind: EventScheduled, ID: e.id, Name: e.name, Occurrence: e.next})
+}
+
+// queueLog queues ev to be logged by flushLog. The caller must hold r.mu.
+func (r *Runner) queueLog(ev Event) {
+	if r.logger == nil {
+		return
+	}
+	ev.Time = r.clock.Now()
+	r.logq = append(r.logq, ev)
+}
+
+// flushLog logs the queued events. Events are logged outside of r.mu so
+// that the Logger may call the Runner's methods.
+func (r *Runner) flushLog() {
+	if r.logger == nil {
+		return
+	}
+	r.mu.Lock()
+	q := r.logq
+	r.logq = nil
+	r.mu.Unlock()
+	for _, ev := range q {
+		r.logger.Log(ev)
+	}
+}
+
+// launch starts a run of the job of e for the occurrence at t. The caller
+// must hold r.mu.
+func (r *Runner) launch(e *runnerEntry, t time.Time) {
+	r.active++
+	if r.drained == nil {
+		r.drained = make(chan struct{})
+		r.jobCtx, r.cancelJobs = context.WithCancel(context.Background())
+	}
+	e.active++
+	e.prev = t
+	var stop chan struct{} // nil if the job is run while r is stopped
+	if r.running {
+		stop = r.stop
+	}
+	var w *semWaiter
+	if r.sem != nil {
+		w = r.sem.enqueue(e.prio)
+	}
+	go r.call(r.jobCtx, e, t, stop, w)
+}
+
+// call runs the job of e for the occurrence at t, with a context derived
+// from ctx, and records when it finishes. If the Runner limits concurrency,
+// call waits for w to be granted a slot first, unless stop is closed.
+func (r *Runner) call(ctx context.Context, e *runnerEntry, t time.Time, stop chan struct{}, w *semWaiter) {
+	defer r.flushLog()
+	defer func() {
+		r.mu.Lock()
+		e.active--
+		if e.active == 0 && !e.pending.IsZero() {
+			t := e.pending
+			e.pending = time.Time{}
+			r.launch(e, t)
+		}
+		r.active--
+		if r.active == 0 {
+			close(r.drained)
+			r.cancelJobs()
+			r.drained, r.jobCtx, r.cancelJobs = nil, nil, nil
+		}
+		r.mu.Unlock()
+	}()
+	if w != nil {
+		select {
+		case <-w.ready:
+		case <-stop:
+		}
+		select {
+		case <-stop:
+			r.sem.cancel(w)
+			return
+		default:
+		}
+		defer r.sem.release()
+	}
+	if r.locker != nil && e.name != "" {
+		release, ok, err := r.locker.TryLock(ctx, e.name, t)
+		if err != nil {
+			r.handleError(e.id, fmt.Errorf("locking %s: %s", e.name, err))
+			return
+		}
+		if !ok {
+			if r.logger != nil {
+				r.logger.Log(Event{Kind: EventLocked, Time: r.clock.Now(), ID: e.id, Name: e.name, Occurrence: t})
+			}
+			return
+		}
+		defer release()
+	}
+	if r.store != nil && e.name != "" {
+		if err := r.store.SaveLastRun(e.name, t); err != nil {
+			r.handleError(e.id, fmt.Errorf("saving last run of %s: %s", e.name, err))
+		}
+	}
+	start := r.clock.Now()
+	if r.logger != nil {
+		r.logger.Log(Event{Kind: EventStarted, Time: start, ID: e.id, Name: e.name, Occurrence: t})
+	}
+	var end func(error)
+	if r.tracer != nil {
+		ctx, end = r.tracer.StartRun(ctx, RunInfo{ID: e.id, Name: e.name, Recurrence: e.r, Scheduled: t, Start: start})
+	}
+	err := r.runJob(ctx, e)
+	if end != nil {
+		end(err)
+	}
+	if r.logger != nil {
+		end := r.clock.Now()
+		r.logger.Log(Event{
+			Kind:       EventFinished,
+			Time:       end,
+			ID:         e.id,
+			Name:       e.name,
+			Occurrence: t,
+			Duration:   end.Sub(start),
+			Err:        err,
+		})
+	}
+	if _, ok := err.(*PanicError); ok {
+		r.recordError(e.id, err)
+	} else if err != nil {
+		r.handleError(e.id, err)
+	}
+	if err == nil {
+		r.runDependents(e.id, t)
+	}
+}
+
+// runDependents triggers the jobs which run after the job with the given
+// ID, for the occurrence t.
+func (r *Runner) runDependents(id JobID, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.running {
+		return
+	}
+	for _, e := range r.entries {
+		if e.paused {
+			continue
+		}
+		for _, dep := range e.after {
+			if dep == id {
+				r.trigger(e, t)
+				break
+			}
+		}
+	}
+}
+
+// handleError records err, concerning the job with the given ID, and
+// passes it to the error handler, if there is one.
+func (r *Runner) handleError(id JobID, err error) {
+	r.recordError(id, err)
+	if r.onError != nil {
+		r.onError(id, err)
+	}
+}
+
+// maxRecentErrors is the number of errors kept for RecentErrors.
+const maxRecentErrors = 20
+
+// A JobError is an error which occurred while running a job.
+type JobError struct {
+	ID   JobID
+	Name string // the job's name, if it has one
+	Time time.Time
+	// Err is the error returned by the job, a *PanicError if it panicked
+	// and the Runner recovered the panic, or an error from the Runner's
+	// Store or Locker.
+	Err error
+}
+
+// RecentErrors returns the Runner's most recent job errors, oldest first.
+// The Runner keeps a limited number of them.
+func (r *Runner) RecentErrors() []JobError {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]JobError(nil), r.errs...)
+}
+
+// recordError records err, concerning the job with the given ID, for
+// RecentErrors.
+func (r *Runner) recordError(id JobID, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	je := JobError{ID: id, Time: r.clock.Now(), Err: err}
+	if i := r.find(id); i >= 0 {
+		je.Name = r.entries[i].name
+	}
+	if len(r.errs) == maxRecentErrors {
+		copy(r.errs, r.errs[1:])
+		r.errs = r.errs[:len(r.errs)-1]
+	}
+	r.errs = append(r.errs, je)
+}
+
+// runJob runs the job of e with a context derived from ctx. If the Runner
+// recovers panics, a panic is returned as a *PanicError.
+func (r *Runner) runJob(ctx context.Context, e *runnerEntry) (err error) {
+	if r.onPanic != nil {
+		defer func() {
+			if v := recover(); v != nil {
+				pe := &PanicError{Value: v, Stack: debug.Stack()}
+				r.onPanic(e.id, pe)
+				err = pe
+			}
+		}()
+	}
+	if e.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.timeout)
+		defer cancel()
+	}
+	return e.job(ctx)
+}
+
+// A semaphore limits the number of concurrent runs. Runs waiting for a slot
+// are granted one in order of priority and then of arrival.
+type semaphore struct {
+	mu      sync.Mutex
+	free    int
+	waiters waiterHeap
+	seq     uint64
+}
+
+type semWaiter struct {
+	prio  int
+	seq   uint64
+	pos   int           // index in the heap, or -1 once granted a slot
+	ready chan struct{} // closed once granted a slot
+}
+
+func newSemaphore(n int) *semaphore {
+	return &semaphore{free: n}
+}
+
+// enqueue returns a waiter for a slot. Its ready channel is closed once it
+// is granted one.
+func (s *semaphore) enqueue(prio int) *semWaiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w := &semWaiter{prio: prio, seq: s.seq, ready: make(chan struct{})}
+	s.seq++
+	if s.free > 0 && len(s.waiters) == 0 {
+		s.free--
+		w.pos = -1
+		close(w.ready)
+		return w
+	}
+	heap.Push(&s.waiters, w)
+	return w
+}
+
+// cancel gives up w's slot, or its place in line if it has not been
+// granted a slot.
+func (s *semaphore) cancel(w *semWaiter) {
+	s.mu.Lock()
+	if w.pos >= 0 {
+		heap.Remove(&s.waiters, w.pos)
+		s.mu.Unlock()
+		return
+	}
+	s.mu.Unlock()
+	s.release()
+}
+
+// release frees a slot, granting it to the first waiter, if any.
+func (s *semaphore) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.waiters) == 0 {
+		s.free++
+		return
+	}
+	w := heap.Pop(&s.waiters).(*semWaiter)
+	w.pos = -1
+	close(w.ready)
+}
+
+// waiterHeap is a heap of waiters, ordered by priority and then arrival.
+type waiterHeap []*semWaiter
+
+func (h waiterHeap) Len() int { return len(h) }
+
+func (h waiterHeap) Less(i, j int) bool {
+	if h[i].prio != h[j].prio {
+		return h[i].prio > h[j].prio
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h waiterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].pos = i
+	h[j].pos = j
+}
+
+func (h *waiterHeap) Push(x interface{}) {
+	w := x.(*semWaiter)
+	w.pos = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *waiterHeap) Pop() interface{} {
+	old := *h
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return w
+}
@@ -0,0 +1,1049 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// waitFor waits up to a few seconds for cond to become true.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestRunner(t *testing.T) {
+	r := NewRunner()
+	var fast, late, once int32
+	r.AddFunc(EveryFrom(time.Now(), 10*time.Millisecond), func() { atomic.AddInt32(&fast, 1) })
+	r.AddFunc(At(time.Now().Add(20*time.Millisecond)), func() { atomic.AddInt32(&once, 1) })
+	r.Start()
+	r.Start() // no effect
+
+	waitFor(t, "three runs", func() bool { return atomic.LoadInt32(&fast) >= 3 })
+	// Jobs added while running are scheduled.
+	r.AddFunc(EveryFrom(time.Now(), 10*time.Millisecond), func() { atomic.AddInt32(&late, 1) })
+	waitFor(t, "late job", func() bool { return atomic.LoadInt32(&late) >= 2 })
+	waitFor(t, "one-shot job", func() bool { return atomic.LoadInt32(&once) == 1 })
+
+	r.Stop()
+	r.Stop() // no effect
+	n := atomic.LoadInt32(&fast)
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&fast); got != n {
+		t.Errorf("job ran %d more times after Stop", got-n)
+	}
+	if got := atomic.LoadInt32(&once); got != 1 {
+		t.Errorf("one-shot job ran %d times", got)
+	}
+
+	// A stopped Runner can be restarted.
+	r.Start()
+	waitFor(t, "runs after restart", func() bool { return atomic.LoadInt32(&fast) >= n+2 })
+	r.Stop()
+}
+
+func TestRunnerRunShutdown(t *testing.T) {
+	r := NewRunner()
+	started := make(chan struct{}, 100)
+	release := make(chan struct{})
+	var finished int32
+	r.AddFunc(EveryFrom(time.Now(), 10*time.Millisecond), func() {
+		started <- struct{}{}
+		<-release
+		atomic.AddInt32(&finished, 1)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error)
+	go func() { errc <- r.Run(ctx) }()
+	<-started
+	if err := r.Run(context.Background()); err == nil {
+		t.Error("second Run succeeded; want error")
+	}
+	cancel()
+	if err := <-errc; err != context.Canceled {
+		t.Errorf("Run returned %v; want %v", err, context.Canceled)
+	}
+
+	// The job is still running, so Shutdown times out.
+	sctx, scancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer scancel()
+	if err := r.Shutdown(sctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown with a job running returned %v; want %v", err, context.DeadlineExceeded)
+	}
+	close(release)
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown returned %v; want nil", err)
+	}
+	if got, want := atomic.LoadInt32(&finished), int32(len(started))+1; got != want {
+		t.Errorf("after Shutdown, %d jobs finished; want %d", got, want)
+	}
+
+	// Run returns nil if the Runner is stopped by Stop.
+	go func() { errc <- r.Run(context.Background()) }()
+	<-started
+	r.Stop()
+	if err := <-errc; err != nil {
+		t.Errorf("Run stopped by Stop returned %v; want nil", err)
+	}
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown returned %v; want nil", err)
+	}
+}
+
+func TestRunnerEntries(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	r := NewRunner(WithClock(clock))
+	hourly := mustParse(t, "0 * * * *")
+	var fast int32
+	id1 := r.AddFunc(hourly, func() {})
+	id2 := r.AddFunc(EveryFrom(start, 10*time.Minute), func() { atomic.AddInt32(&fast, 1) })
+	id3 := r.AddFunc(hourly, func() {})
+	if id1 == 0 || id1 == id2 || id2 == id3 || id1 == id3 {
+		t.Fatalf("AddFunc returned IDs %d, %d, %d; want distinct nonzero IDs", id1, id2, id3)
+	}
+	for _, info := range r.Entries() {
+		if !info.Prev.IsZero() || !info.Next.IsZero() {
+			t.Errorf("before Start, job %d has Prev %s, Next %s; want zero times", info.ID, info.Prev, info.Next)
+		}
+	}
+
+	r.Start()
+	defer r.Stop()
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(10 * time.Minute)
+	waitFor(t, "fast job", func() bool { return atomic.LoadInt32(&fast) == 1 })
+	entries := r.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Entries() returned %d entries; want 3", len(entries))
+	}
+	for i, id := range []JobID{id1, id2, id3} {
+		if entries[i].ID != id {
+			t.Errorf("Entries()[%d].ID = %d; want %d", i, entries[i].ID, id)
+		}
+	}
+	if e := entries[0]; e.Recurrence != hourly || !e.Prev.IsZero() || !e.Next.Equal(start.Add(time.Hour)) {
+		t.Errorf("hourly job: got %+v", e)
+	}
+	if e := entries[1]; !e.Prev.Equal(start.Add(10*time.Minute)) || !e.Next.Equal(start.Add(20*time.Minute)) {
+		t.Errorf("fast job: got Prev %s, Next %s; want 00:10 and 00:20", e.Prev, e.Next)
+	}
+
+	if !r.Remove(id2) {
+		t.Errorf("Remove(%d) = false", id2)
+	}
+	if r.Remove(id2) {
+		t.Errorf("second Remove(%d) = true", id2)
+	}
+	clock.advance(30 * time.Minute)
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	if got := atomic.LoadInt32(&fast); got != 1 {
+		t.Errorf("removed job ran %d more times", got-1)
+	}
+	var ids []JobID
+	for _, info := range r.Entries() {
+		ids = append(ids, info.ID)
+	}
+	if diff := cmp.Diff(ids, []JobID{id1, id3}); diff != "" {
+		t.Errorf("after Remove, entry IDs (-got, +want):\n%s", diff)
+	}
+	if id4 := r.AddFunc(hourly, func() {}); id4 <= id3 {
+		t.Errorf("AddFunc after Remove returned ID %d; want more than %d", id4, id3)
+	}
+}
+
+func TestRunnerPanicHandler(t *testing.T) {
+	var mu sync.Mutex
+	var errs []*PanicError
+	var ids []JobID
+	r := NewRunner(WithPanicHandler(func(id JobID, err *PanicError) {
+		mu.Lock()
+		defer mu.Unlock()
+		ids = append(ids, id)
+		errs = append(errs, err)
+	}))
+	var runs int32
+	id := r.AddFunc(EveryFrom(time.Now(), 10*time.Millisecond), func() {
+		atomic.AddInt32(&runs, 1)
+		panic("boom")
+	})
+	r.Start()
+	waitFor(t, "repeated runs", func() bool { return atomic.LoadInt32(&runs) >= 3 })
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got, want := len(errs), int(atomic.LoadInt32(&runs)); got != want {
+		t.Fatalf("panic handler called %d times; want %d", got, want)
+	}
+	for i, err := range errs {
+		if ids[i] != id {
+			t.Errorf("panic handler got ID %d; want %d", ids[i], id)
+		}
+		if err.Value != "boom" {
+			t.Errorf("PanicError.Value = %v; want boom", err.Value)
+		}
+		if !strings.Contains(string(err.Stack), "TestRunnerPanicHandler") {
+			t.Errorf("PanicError.Stack does not mention the panicking function:\n%s", err.Stack)
+		}
+		if got, want := err.Error(), "job panicked: boom"; got != want {
+			t.Errorf("Error() = %q; want %q", got, want)
+		}
+	}
+
+	// A nil handler still recovers.
+	r = NewRunner(WithPanicHandler(nil))
+	r.AddFunc(EveryFrom(time.Now(), 10*time.Millisecond), func() { panic("ignored") })
+	r.Start()
+	time.Sleep(30 * time.Millisecond)
+	r.Shutdown(context.Background())
+}
+
+func TestRunnerOverlapSkip(t *testing.T) {
+	r := NewRunner()
+	release := make(chan struct{})
+	var runs, concurrent, maxConcurrent int32
+	job := func() {
+		c := atomic.AddInt32(&concurrent, 1)
+		for {
+			m := atomic.LoadInt32(&maxConcurrent)
+			if c <= m || atomic.CompareAndSwapInt32(&maxConcurrent, m, c) {
+				break
+			}
+		}
+		atomic.AddInt32(&runs, 1)
+		<-release
+		atomic.AddInt32(&concurrent, -1)
+	}
+	id := r.AddFunc(EveryFrom(time.Now(), 5*time.Millisecond), job, WithOverlap(OverlapSkip))
+	r.Start()
+	waitFor(t, "first run", func() bool { return atomic.LoadInt32(&runs) == 1 })
+	time.Sleep(50 * time.Millisecond)
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("with a run in progress, job ran %d times; want 1", got)
+	}
+	prev := r.Entries()[0].Prev
+	close(release)
+	waitFor(t, "later runs", func() bool { return atomic.LoadInt32(&runs) >= 3 })
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&maxConcurrent); got != 1 {
+		t.Errorf("job had %d concurrent runs; want 1", got)
+	}
+	if info := r.Entries()[0]; info.ID != id || !info.Prev.After(prev) {
+		t.Errorf("after skipping, Prev = %s; want after %s", info.Prev, prev)
+	}
+}
+
+func TestRunnerOverlapDelay(t *testing.T) {
+	r := NewRunner()
+	release := make(chan struct{})
+	var runs, concurrent int32
+	job := func() {
+		if atomic.AddInt32(&concurrent, 1) > 1 {
+			t.Error("runs overlapped")
+		}
+		if atomic.AddInt32(&runs, 1) == 1 {
+			<-release
+		}
+		atomic.AddInt32(&concurrent, -1)
+	}
+	r.AddFunc(EveryFrom(time.Now(), 5*time.Millisecond), job, WithOverlap(OverlapDelay))
+	r.Start()
+	waitFor(t, "first run", func() bool { return atomic.LoadInt32(&runs) == 1 })
+	time.Sleep(50 * time.Millisecond) // several occurrences pass
+	r.Stop()
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("with a run in progress, job ran %d times; want 1", got)
+	}
+	// Stopping the Runner discards the delayed run.
+	close(release)
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("after Stop, job ran %d times; want 1", got)
+	}
+
+	// The missed occurrences are coalesced into one delayed run, for the
+	// latest of them.
+	atomic.StoreInt32(&runs, 0)
+	release = make(chan struct{})
+	r.Start()
+	waitFor(t, "first run", func() bool { return atomic.LoadInt32(&runs) == 1 })
+	time.Sleep(50 * time.Millisecond)
+	prev := r.Entries()[0].Prev
+	latest := time.Now().Add(-25 * time.Millisecond)
+	close(release)
+	waitFor(t, "delayed run", func() bool { return atomic.LoadInt32(&runs) >= 2 })
+	r.Stop()
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if info := r.Entries()[0]; !info.Prev.After(prev) || info.Prev.Before(latest) {
+		t.Errorf("after delayed run, Prev = %s; want after %s", info.Prev, latest)
+	}
+}
+
+func TestRunnerMaxConcurrency(t *testing.T) {
+	r := NewRunner(WithMaxConcurrency(2))
+	release := make(chan struct{})
+	var started, concurrent int32
+	for i := 0; i < 5; i++ {
+		r.AddFunc(At(time.Now().Add(10*time.Millisecond)), func() {
+			if atomic.AddInt32(&concurrent, 1) > 2 {
+				t.Error("more than 2 jobs ran at once")
+			}
+			atomic.AddInt32(&started, 1)
+			<-release
+			atomic.AddInt32(&concurrent, -1)
+		})
+	}
+	r.Start()
+	waitFor(t, "two jobs", func() bool { return atomic.LoadInt32(&started) == 2 })
+	time.Sleep(20 * time.Millisecond)
+	if got := atomic.LoadInt32(&started); got != 2 {
+		t.Errorf("%d jobs started; want 2", got)
+	}
+	close(release)
+	waitFor(t, "all jobs", func() bool { return atomic.LoadInt32(&started) == 5 })
+	r.Stop()
+
+	// Jobs waiting for a slot when the Runner stops do not run.
+	release = make(chan struct{})
+	atomic.StoreInt32(&started, 0)
+	for _, info := range r.Entries() {
+		r.Remove(info.ID)
+	}
+	for i := 0; i < 5; i++ {
+		r.AddFunc(At(time.Now().Add(10*time.Millisecond)), func() {
+			atomic.AddInt32(&started, 1)
+			<-release
+		})
+	}
+	r.Start()
+	waitFor(t, "two jobs", func() bool { return atomic.LoadInt32(&started) == 2 })
+	r.Stop()
+	close(release)
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got := atomic.LoadInt32(&started); got != 2 {
+		t.Errorf("after Stop, %d jobs started; want 2", got)
+	}
+}
+
+func TestRunnerJobContext(t *testing.T) {
+	errs := make(chan error, 10)
+	r := NewRunner(WithErrorHandler(func(id JobID, err error) { errs <- err }))
+	var runs int32
+	// The job outlives its timeout only by waiting for its context.
+	r.Add(At(time.Now().Add(10*time.Millisecond)), func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		<-ctx.Done()
+		return ctx.Err()
+	}, WithTimeout(20*time.Millisecond))
+	r.Start()
+	select {
+	case err := <-errs:
+		if err != context.DeadlineExceeded {
+			t.Errorf("job returned %v; want %v", err, context.DeadlineExceeded)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not canceled after its timeout")
+	}
+
+	// Shutdown cancels the jobs still running when its context is done.
+	started := make(chan struct{})
+	r.Add(At(time.Now().Add(10*time.Millisecond)), func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return errors.New("canceled")
+	})
+	<-started
+	sctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Shutdown(sctx); err != context.DeadlineExceeded {
+		t.Errorf("Shutdown returned %v; want %v", err, context.DeadlineExceeded)
+	}
+	select {
+	case err := <-errs:
+		if err.Error() != "canceled" {
+			t.Errorf("job returned %v; want canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("job was not canceled by Shutdown")
+	}
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Errorf("second Shutdown returned %v; want nil", err)
+	}
+}
+
+func TestRunnerMiddleware(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next Job) Job {
+			return func(ctx context.Context) error {
+				mu.Lock()
+				calls = append(calls, name)
+				mu.Unlock()
+				return next(ctx)
+			}
+		}
+	}
+	errs := make(chan error, 1)
+	r := NewRunner(
+		WithMiddleware(record("runner1"), record("runner2")),
+		WithErrorHandler(func(_ JobID, err error) { errs <- err }),
+	)
+	r.Add(At(time.Now().Add(10*time.Millisecond)), func(context.Context) error {
+		mu.Lock()
+		calls = append(calls, "job")
+		mu.Unlock()
+		return errors.New("job failed")
+	}, WithJobMiddleware(record("job1")), WithJobMiddleware(record("job2")))
+	r.Start()
+	defer r.Stop()
+	select {
+	case err := <-errs:
+		if err.Error() != "job failed" {
+			t.Errorf("error handler got %v; want job failed", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("job did not run")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(calls, []string{"runner1", "runner2", "job1", "job2", "job"}); diff != "" {
+		t.Errorf("call order (-got, +want):\n%s", diff)
+	}
+}
+
+func TestRunnerLogger(t *testing.T) {
+	var mu sync.Mutex
+	var events []Event
+	var r *Runner
+	r = NewRunner(WithLogger(LoggerFunc(func(ev Event) {
+		r.Entries() // the Logger may call the Runner
+		mu.Lock()
+		events = append(events, ev)
+		mu.Unlock()
+	})))
+	release := make(chan struct{})
+	var runs int32
+	at := time.Now().Add(10 * time.Millisecond)
+	id := r.Add(At(at), func(context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return errors.New("failed")
+	}, WithName("once"))
+	slow := r.AddFunc(EveryFrom(time.Now(), 5*time.Millisecond), func() { <-release }, WithOverlap(OverlapSkip))
+	r.Start()
+	waitFor(t, "skipped occurrence", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ev := range events {
+			if ev.Kind == EventSkipped && ev.ID == slow {
+				return true
+			}
+		}
+		return false
+	})
+	waitFor(t, "one-shot job", func() bool { return atomic.LoadInt32(&runs) == 1 })
+	close(release)
+	if err := r.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var got []EventKind
+	for _, ev := range events {
+		if ev.ID != id {
+			continue
+		}
+		if ev.Name != "once" || ev.Time.IsZero() {
+			t.Errorf("event %v has name %q, time %s", ev, ev.Name, ev.Time)
+		}
+		got = append(got, ev.Kind)
+		switch ev.Kind {
+		case EventStarted:
+			if !ev.Occurrence.Equal(at) {
+				t.Errorf("EventStarted has occurrence %s; want %s", ev.Occurrence, at)
+			}
+		case EventFinished:
+			if ev.Err == nil || ev.Err.Error() != "failed" || ev.Duration < 0 {
+				t.Errorf("EventFinished has error %v, duration %s", ev.Err, ev.Duration)
+			}
+		}
+	}
+	want := []EventKind{EventScheduled, EventStarted, EventScheduled, EventFinished}
+	if len(got) == 4 && got[1] == EventScheduled {
+		// The job may start before or after its next occurrence is logged.
+		want[1], want[2] = want[2], want[1]
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("events for one-shot job (-got, +want):\n%s", diff)
+	}
+}
+
+func TestRunnerWait(t *testing.T) {
+	r := NewRunner()
+	if err := r.Wait(context.Background()); err != nil {
+		t.Errorf("Wait with no jobs running returned %v", err)
+	}
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var runs int32
+	r.AddFunc(At(time.Now().Add(10*time.Millisecond)), func() {
+		close(started)
+		<-release
+		atomic.AddInt32(&runs, 1)
+	})
+	r.Start()
+	defer r.Stop()
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := r.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait with a job running returned %v; want %v", err, context.DeadlineExceeded)
+	}
+	close(release)
+	if err := r.Wait(context.Background()); err != nil {
+		t.Errorf("Wait returned %v", err)
+	}
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("after Wait, job finished %d times; want 1", got)
+	}
+	if !r.Stats().Running {
+		t.Error("Wait stopped the Runner")
+	}
+}
+
+func TestRunnerCatchUp(t *testing.T) {
+	now := time.Date(2026, 1, 4, 12, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: now}
+	store := NewMemoryStore()
+	lastRun := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	for _, name := range []string{"skip", "once", "all"} {
+		store.SaveLastRun(name, lastRun)
+	}
+	var mu sync.Mutex
+	started := make(map[string][]time.Time)
+	r := NewRunner(WithClock(clock), WithStore(store), WithLogger(LoggerFunc(func(ev Event) {
+		if ev.Kind == EventStarted {
+			mu.Lock()
+			started[ev.Name] = append(started[ev.Name], ev.Occurrence)
+			mu.Unlock()
+		}
+	})))
+	daily := mustParse(t, "0 3 * * *")
+	r.AddFunc(daily, func() {}, WithName("skip"))
+	r.AddFunc(daily, func() {}, WithName("once"), WithCatchUp(CatchUpOnce))
+	r.AddFunc(daily, func() {}, WithName("all"), WithCatchUp(CatchUpAll))
+	r.AddFunc(daily, func() {}, WithName("new"), WithCatchUp(CatchUpAll)) // never ran
+	r.Start()
+	defer r.Stop()
+	waitFor(t, "catch-up runs", func() bool {
+		clock.advance(0) // fire timers set for missed occurrences
+		mu.Lock()
+		defer mu.Unlock()
+		return len(started["once"]) == 1 && len(started["all"]) == 3
+	})
+	r.Wait(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[string][]time.Time{
+		"once": {time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)},
+		"all": {
+			time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC),
+			time.Date(2026, 1, 3, 3, 0, 0, 0, time.UTC),
+			time.Date(2026, 1, 4, 3, 0, 0, 0, time.UTC),
+		},
+	}
+	if diff := cmp.Diff(started, want); diff != "" {
+		t.Errorf("catch-up runs (-got, +want):\n%s", diff)
+	}
+	for _, info := range r.Entries() {
+		if want := time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC); !info.Next.Equal(want) {
+			t.Errorf("after catching up, job %s has Next %s; want %s", info.Name, info.Next, want)
+		}
+	}
+}
+
+func TestRunnerRunNow(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := NewRunner(WithClock(clock))
+	release := make(chan struct{})
+	var runs, skipRuns int32
+	id := r.AddFunc(mustParse(t, "0 3 * * *"), func() { atomic.AddInt32(&runs, 1) })
+	skip := r.AddFunc(mustParse(t, "0 3 * * *"), func() {
+		atomic.AddInt32(&skipRuns, 1)
+		<-release
+	}, WithOverlap(OverlapSkip))
+
+	// RunNow works before the Runner starts.
+	if !r.RunNow(id) {
+		t.Fatal("RunNow returned false")
+	}
+	r.Wait(context.Background())
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("after RunNow, job ran %d times; want 1", got)
+	}
+
+	r.Start()
+	defer r.Stop()
+	next := r.Entries()[0].Next
+	clock.advance(time.Hour)
+	r.RunNow(id)
+	r.Wait(context.Background())
+	info := r.Entries()[0]
+	if got := atomic.LoadInt32(&runs); got != 2 {
+		t.Errorf("after second RunNow, job ran %d times; want 2", got)
+	}
+	if !info.Next.Equal(next) || !info.Prev.Equal(clock.Now()) {
+		t.Errorf("after RunNow, Prev = %s, Next = %s; want %s, %s", info.Prev, info.Next, clock.Now(), next)
+	}
+
+	// RunNow respects the Overlap policy.
+	r.RunNow(skip)
+	waitFor(t, "first run", func() bool { return atomic.LoadInt32(&skipRuns) == 1 })
+	r.RunNow(skip)
+	close(release)
+	r.Wait(context.Background())
+	if got := atomic.LoadInt32(&skipRuns); got != 1 {
+		t.Errorf("with OverlapSkip, job ran %d times; want 1", got)
+	}
+
+	if r.RunNow(12345) {
+		t.Error("RunNow of unknown job returned true")
+	}
+}
+
+func TestRunnerSkip(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	r := NewRunner(WithClock(clock))
+	var mu sync.Mutex
+	runs := make(map[string][]time.Time)
+	add := func(name string) JobID {
+		return r.AddFunc(mustParse(t, "0 3 * * *"), func() {
+			mu.Lock()
+			runs[name] = append(runs[name], clock.Now())
+			mu.Unlock()
+		})
+	}
+	next, until := add("next"), add("until")
+
+	// Before starting, SkipNext skips the first occurrence after now.
+	skipped, ok := r.SkipNext(next)
+	if want := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC); !ok || !skipped.Equal(want) {
+		t.Errorf("SkipNext = %s, %t; want %s", skipped, ok, want)
+	}
+	if !r.SkipUntil(until, time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Error("SkipUntil returned false")
+	}
+	r.Start()
+	defer r.Stop()
+	// step advances the clock by d and waits for the jobs due by then.
+	step := func(d time.Duration) {
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.advance(d)
+		waitFor(t, "runs", func() bool { return r.Entries()[0].Next.After(clock.Now()) })
+		r.Wait(context.Background())
+	}
+	step(3 * time.Hour)
+	for i := 0; i < 3; i++ {
+		step(24 * time.Hour)
+	}
+	// Once the Runner is running, SkipNext skips the scheduled occurrence.
+	if skipped, _ := r.SkipNext(next); !skipped.Equal(time.Date(2026, 1, 5, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("SkipNext while running skipped %s", skipped)
+	}
+	step(24 * time.Hour)
+
+	day := func(d int) time.Time { return time.Date(2026, 1, d, 3, 0, 0, 0, time.UTC) }
+	mu.Lock()
+	defer mu.Unlock()
+	want := map[string][]time.Time{
+		"next":  {day(2), day(3), day(4)},
+		"until": {day(3), day(4), day(5)},
+	}
+	if diff := cmp.Diff(runs, want); diff != "" {
+		t.Errorf("runs (-got, +want):\n%s", diff)
+	}
+	if got := r.Entries()[1].SkipUntil; !got.Equal(time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("SkipUntil = %s", got)
+	}
+	if _, ok := r.SkipNext(12345); ok || r.SkipUntil(12345, start) {
+		t.Error("skipping unknown job reported true")
+	}
+}
+
+func TestRunnerPause(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := NewRunner(WithClock(clock))
+	var runs, depRuns int32
+	id := r.AddFunc(mustParse(t, "0 3 * * *"), func() { atomic.AddInt32(&runs, 1) })
+	r.AddFunc(nil, func() { atomic.AddInt32(&depRuns, 1) }, After(id))
+	r.Start()
+	defer r.Stop()
+	step := func() {
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.advance(24 * time.Hour)
+		waitFor(t, "runs", func() bool { return r.Entries()[0].Next.After(clock.Now()) })
+		r.Wait(context.Background())
+	}
+
+	if !r.Pause(id) || !r.Pause(id+1) {
+		t.Fatal("Pause returned false")
+	}
+	if !r.Entries()[0].Paused {
+		t.Error("after Pause, Paused = false")
+	}
+	step()
+	if got := atomic.LoadInt32(&runs); got != 0 {
+		t.Errorf("while paused, job ran %d times", got)
+	}
+	// RunNow works while paused, but dependents stay paused.
+	r.RunNow(id)
+	r.Wait(context.Background())
+	if got := atomic.LoadInt32(&runs); got != 1 {
+		t.Errorf("after RunNow while paused, job ran %d times; want 1", got)
+	}
+	if got := atomic.LoadInt32(&depRuns); got != 0 {
+		t.Errorf("paused dependent ran %d times", got)
+	}
+
+	r.Resume(id)
+	r.Resume(id + 1)
+	step()
+	if got := atomic.LoadInt32(&runs); got != 2 {
+		t.Errorf("after Resume, job ran %d times; want 2", got)
+	}
+	waitFor(t, "dependent", func() bool { return atomic.LoadInt32(&depRuns) == 1 })
+	if r.Pause(12345) || r.Resume(12345) {
+		t.Error("pausing unknown job reported true")
+	}
+}
+
+func TestRunnerRecentErrors(t *testing.T) {
+	r := NewRunner(WithPanicHandler(func(JobID, *PanicError) {}))
+	fail := r.Add(nil, func(context.Context) error { return errors.New("failed") }, WithName("fail"))
+	panicky := r.AddFunc(nil, func() { panic("boom") })
+	r.RunNow(fail)
+	r.Wait(context.Background())
+	r.RunNow(panicky)
+	r.Wait(context.Background())
+
+	errs := r.RecentErrors()
+	if len(errs) != 2 {
+		t.Fatalf("RecentErrors() = %v; want 2 errors", errs)
+	}
+	if e := errs[0]; e.ID != fail || e.Name != "fail" || e.Err.Error() != "failed" || e.Time.IsZero() {
+		t.Errorf("first error = %+v", e)
+	}
+	if e := errs[1]; e.ID != panicky || e.Err.Error() != "job panicked: boom" {
+		t.Errorf("second error = %+v", e)
+	}
+
+	for i := 0; i < maxRecentErrors; i++ {
+		r.RunNow(fail)
+		r.Wait(context.Background())
+	}
+	errs = r.RecentErrors()
+	if len(errs) != maxRecentErrors || errs[0].ID != fail {
+		t.Errorf("after many errors, got %d errors, first from job %d", len(errs), errs[0].ID)
+	}
+}
+
+func TestRunnerReboot(t *testing.T) {
+	r := NewRunner()
+	var runs, pausedRuns, lateRuns int32
+	var prev time.Time
+	r.Add(Reboot{}, func(context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}, WithJobMiddleware(func(job Job) Job {
+		return func(ctx context.Context) error {
+			prev = time.Now()
+			return job(ctx)
+		}
+	}))
+	paused := r.AddFunc(Reboot{}, func() { atomic.AddInt32(&pausedRuns, 1) })
+	r.Pause(paused)
+
+	r.Start()
+	r.Wait(context.Background())
+	r.AddFunc(Reboot{}, func() { atomic.AddInt32(&lateRuns, 1) })
+	r.Stop()
+	r.Start()
+	defer r.Stop()
+	r.Wait(context.Background())
+	if got := atomic.LoadInt32(&runs); got != 1 || prev.IsZero() {
+		t.Errorf("Reboot job ran %d times; want once, through its middleware", got)
+	}
+	if got := atomic.LoadInt32(&pausedRuns); got != 0 {
+		t.Errorf("paused Reboot job ran %d times", got)
+	}
+	if got := atomic.LoadInt32(&lateRuns); got != 0 {
+		t.Errorf("Reboot job added after Start ran %d times", got)
+	}
+	if info := r.Entries()[0]; info.Prev.IsZero() || !info.Next.IsZero() {
+		t.Errorf("Reboot job has Prev = %s, Next = %s", info.Prev, info.Next)
+	}
+}
+
+func TestRunnerNeverFires(t *testing.T) {
+	// A valid schedule which never fires has no occurrences.
+	never := mustParse(t, "0 0 30 2 *")
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := NewRunner(WithClock(clock))
+	var runs int32
+	id := r.AddFunc(never, func() { atomic.AddInt32(&runs, 1) })
+	r.AddFunc(mustParse(t, "0 * * * *"), func() {})
+
+	started := make(chan struct{})
+	go func() {
+		r.Start()
+		close(started)
+	}()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return")
+	}
+	defer r.Stop()
+	r.AddFunc(never.In(time.UTC), func() {})
+
+	infos := r.Entries()
+	if !infos[0].Next.IsZero() || !infos[2].Next.IsZero() {
+		t.Errorf("jobs which never fire have Next = %s, %s; want zero", infos[0].Next, infos[2].Next)
+	}
+	if want := time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC); !infos[1].Next.Equal(want) {
+		t.Errorf("hourly job has Next = %s; want %s", infos[1].Next, want)
+	}
+	r.RunNow(id)
+	waitFor(t, "RunNow", func() bool { return atomic.LoadInt32(&runs) == 1 })
+}
+
+func TestRunnerClockJump(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	r := NewRunner(WithClock(clock))
+	var runs int32
+	r.AddFunc(mustParse(t, "0 3 * * *"), func() { atomic.AddInt32(&runs, 1) })
+	r.Start()
+	defer r.Stop()
+
+	// The wall clock is set forward past the job's time. The Runner
+	// notices within maxWaitTimer rather than when its timer for 03:00
+	// would have run out.
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.jump(3*time.Hour + time.Minute)
+	clock.advance(maxWaitTimer)
+	waitFor(t, "job run", func() bool { return atomic.LoadInt32(&runs) == 1 })
+	if got, want := r.Entries()[0].Next, time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("after the jump, Next = %s; want %s", got, want)
+	}
+}
+
+func TestRunnerJitter(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	r := NewRunner(WithClock(clock))
+	var seededRuns, randomRuns int32
+	daily := mustParse(t, "0 3 * * *")
+	r.AddFunc(daily, func() { atomic.AddInt32(&seededRuns, 1) }, WithSeededJitter(10*time.Minute, 42))
+	r.AddFunc(daily, func() { atomic.AddInt32(&randomRuns, 1) }, WithJitter(10*time.Minute))
+	delay := time.Duration(rand.New(rand.NewSource(42)).Int63n(int64(10 * time.Minute)))
+	r.Start()
+	defer r.Stop()
+
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(3*time.Hour + delay - time.Nanosecond)
+	if got := atomic.LoadInt32(&seededRuns); got != 0 {
+		t.Fatalf("job with %s jitter ran before its delay", delay)
+	}
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(time.Nanosecond)
+	waitFor(t, "seeded run", func() bool { return atomic.LoadInt32(&seededRuns) == 1 })
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(10 * time.Minute)
+	waitFor(t, "random run", func() bool { return atomic.LoadInt32(&randomRuns) == 1 })
+	for _, info := range r.Entries() {
+		if want := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC); !info.Prev.Equal(want) {
+			t.Errorf("job %d has Prev %s; want the occurrence %s", info.ID, info.Prev, want)
+		}
+	}
+
+	// Random delays are spread over the range.
+	e := &runnerEntry{r: daily}
+	WithJitter(time.Second)(e)
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		e.setNext(start)
+		if e.delay < 0 || e.delay >= time.Second {
+			t.Fatalf("delay %s out of range", e.delay)
+		}
+		seen[e.delay] = true
+	}
+	if len(seen) < 50 {
+		t.Errorf("100 random delays had only %d distinct values", len(seen))
+	}
+}
+
+func TestRunnerAfter(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	var mu sync.Mutex
+	var order []string
+	started := make(map[string][]time.Time)
+	r := NewRunner(WithClock(clock), WithLogger(LoggerFunc(func(ev Event) {
+		if ev.Kind == EventStarted {
+			mu.Lock()
+			started[ev.Name] = append(started[ev.Name], ev.Occurrence)
+			mu.Unlock()
+		}
+	})))
+	record := func(name string, err error) Job {
+		return func(context.Context) error {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+			return err
+		}
+	}
+	extract := r.Add(mustParse(t, "0 3 * * *"), record("extract", nil), WithName("extract"))
+	transform := r.Add(nil, record("transform", nil), WithName("transform"), After(extract))
+	r.Add(nil, record("load", nil), WithName("load"), After(transform))
+	// A job may also have its own schedule.
+	r.Add(mustParse(t, "0 12 * * *"), record("report", nil), WithName("report"), After(transform))
+	failing := r.Add(nil, record("failing", errors.New("failed")), WithName("failing"), After(extract))
+	r.Add(nil, record("never", nil), WithName("never"), After(failing), After(9999))
+
+	r.Start()
+	defer r.Stop()
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(3 * time.Hour)
+	waitFor(t, "chain", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 5
+	})
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(9 * time.Hour)
+	waitFor(t, "report", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 6
+	})
+	r.Wait(context.Background())
+
+	mu.Lock()
+	defer mu.Unlock()
+	at3 := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	want := map[string][]time.Time{
+		"extract":   {at3},
+		"transform": {at3},
+		"failing":   {at3},
+		"load":      {at3},
+		"report":    {at3, time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	if diff := cmp.Diff(started, want); diff != "" {
+		t.Errorf("runs (-got, +want):\n%s", diff)
+	}
+	pos := make(map[string]int)
+	for i, name := range order {
+		if _, ok := pos[name]; !ok {
+			pos[name] = i
+		}
+	}
+	for _, dep := range [][2]string{{"extract", "transform"}, {"transform", "load"}, {"transform", "report"}, {"extract", "failing"}} {
+		if pos[dep[0]] > pos[dep[1]] {
+			t.Errorf("%s ran before %s; order %v", dep[1], dep[0], order)
+		}
+	}
+}
+
+func TestRunnerPriority(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	r := NewRunner(WithClock(clock), WithMaxConcurrency(1))
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+	hourly := mustParse(t, "0 * * * *")
+	r.AddFunc(hourly, record("low"), WithPriority(-1))
+	r.AddFunc(hourly, record("normal1"))
+	r.AddFunc(hourly, record("high"), WithPriority(5))
+	r.AddFunc(hourly, record("normal2"))
+	r.AddFunc(hourly, record("critical"), WithPriority(10))
+	r.Start()
+	defer r.Stop()
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(time.Hour)
+	waitFor(t, "runs", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(order) == 5
+	})
+	mu.Lock()
+	defer mu.Unlock()
+	if diff := cmp.Diff(order, []string{"critical", "high", "normal1", "normal2", "low"}); diff != "" {
+		t.Errorf("run order (-got, +want):\n%s", diff)
+	}
+}
+
+func TestSemaphore(t *testing.T) {
+	s := newSemaphore(1)
+	first := s.enqueue(0)
+	select {
+	case <-first.ready:
+	default:
+		t.Fatal("first waiter not granted the free slot")
+	}
+	low, high, canceled := s.enqueue(0), s.enqueue(1), s.enqueue(2)
+	s.cancel(canceled)
+	s.release()
+	select {
+	case <-high.ready:
+	default:
+		t.Fatal("high-priority waiter not granted the slot")
+	}
+	select {
+	case <-low.ready:
+		t.Fatal("low-priority waiter granted a slot in use")
+	default:
+	}
+	s.cancel(high) // gives up the granted slot
+	<-low.ready
+	s.release()
+	if s.free != 1 || len(s.waiters) != 0 {
+		t.Errorf("after all released, free = %d, %d waiting", s.free, len(s.waiters))
+	}
+}
@@ -0,0 +1,102 @@
+package cron
+
+import (
+	mathbits "math/bits"
+	"sort"
+	"time"
+)
+
+// NextAfterSkipping returns the (n+1)th time after t at which s fires,
+// skipping n times, so that NextAfterSkipping(t, 0) is Next(t). It is meant
+// for paging through the times at which a schedule fires.
+//
+// For a time in UTC, NextAfterSkipping counts the times at which s fires
+// in whole days, months, and years from s's fields, so it takes about the
+// same time however large n is. In other locations, where daylight saving
+// time changes can skip or repeat times, it calls Next n+1 times.
+//
+// Like Next, NextAfterSkipping returns the zero Time if s never fires, and
+// panics if s is not valid. It also panics if n is negative.
+func (s Schedule) NextAfterSkipping(t time.Time, n int) time.Time {
+	if err := s.Validate(); err != nil {
+		panic("NextAfterSkipping() called on invalid schedule: " + err.Error())
+	}
+	if n < 0 {
+		panic("cron: negative n for NextAfterSkipping")
+	}
+	if s.normalize() == (Schedule{}) {
+		return time.Time{}
+	}
+	if t.Location() != time.UTC {
+		for i := 0; i <= n; i++ {
+			t = s.next(t)
+		}
+		return t
+	}
+	return s.skipUTC(t, n)
+}
+
+func (s Schedule) skipUTC(t time.Time, n int) time.Time {
+	t = s.nextUTC(t)
+	if n == 0 {
+		return t
+	}
+	f := s.fieldBits()
+	var times []int // the minutes of the day at which s fires
+	for h := 0; h < 24; h++ {
+		if f[1]&(1<<uint(h)) == 0 {
+			continue
+		}
+		for m := 0; m < 60; m++ {
+			if f[0]&(1<<uint(m)) != 0 {
+				times = append(times, h*60+m)
+			}
+		}
+	}
+	perDay := len(times)
+	at := func(days int64, minOfDay int) time.Time {
+		return time.Unix((days*minutesPerDay+int64(minOfDay))*60, 0).UTC()
+	}
+
+	// Skip the rest of t's day.
+	m := t.Unix() / 60
+	days := floorDiv(m, minutesPerDay)
+	i := sort.SearchInts(times, int(m-days*minutesPerDay))
+	if i+n < perDay {
+		return at(days, times[i+n])
+	}
+	// From here on, n is the number of times to skip after t's day.
+	n -= perDay - i
+
+	// Skip the rest of t's month, then whole months and years. The month
+	// bits have bit 0 for day 1.
+	year, month, day := civilDate(days)
+	bits := s.monthDays(year, time.Month(month)) &^ (1<<uint(day) - 1)
+	cycle := -1
+	for {
+		if c := mathbits.OnesCount64(bits) * perDay; n >= c {
+			n -= c
+			if month++; month > 12 {
+				year, month = year+1, 1
+				if cycle < 0 {
+					cycle = s.cycleDays(year) * perDay
+				}
+				year += 400 * (n / cycle)
+				n %= cycle
+				for c := s.yearDays(year) * perDay; n >= c; c = s.yearDays(year) * perDay {
+					n -= c
+					year++
+				}
+			}
+			bits = s.monthDays(year, time.Month(month))
+			continue
+		}
+		// Skip whole days.
+		for n >= perDay {
+			n -= perDay
+			bits &= bits - 1
+		}
+		d := mathbits.TrailingZeros64(bits) + 1
+		return at(daysSinceEpoch(year, month, d), times[n])
+	}
+}
@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextAfterSkipping(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expr := range []string{
+		"* * * * *",
+		"*/7 3,15 * * *",
+		"0 0 * * MON-FRI",
+		"30 12 29 2 *",
+		"0 0 31 * *",
+		"5 4 13 * FRI",
+		"0 2 * 3 SUN",
+	} {
+		s := mustParse(t, expr)
+		for _, from := range []time.Time{
+			time.Date(2026, 2, 27, 15, 10, 0, 0, time.UTC),
+			time.Date(2099, 12, 31, 23, 59, 0, 0, time.UTC),
+			time.Date(2026, 3, 1, 0, 0, 0, 0, ny),
+		} {
+			want := from
+			for n := 0; n < 100; n++ {
+				want = s.Next(want)
+				if got := s.NextAfterSkipping(from, n); !got.Equal(want) || got.Location() != want.Location() {
+					t.Errorf("%q.NextAfterSkipping(%s, %d) = %s; want %s", expr, from, n, got, want)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestNextAfterSkippingNeverFires(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := mustParse(t, "0 0 30 2 *")
+	for _, from := range []time.Time{
+		time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2026, 1, 1, 0, 0, 0, 0, ny),
+	} {
+		for _, n := range []int{0, 1, 1000} {
+			if got := s.NextAfterSkipping(from, n); !got.IsZero() {
+				t.Errorf("NextAfterSkipping(%s, %d) = %s; want the zero Time", from, n, got)
+			}
+		}
+	}
+}
+
+func TestNextAfterSkippingLarge(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		expr string
+		n    int
+		want time.Time
+	}{
+		{"* * * * *", 1e7, from.Add((1e7 + 1) * time.Minute)},
+		{"0 0 * * *", 146097*3 - 1, time.Date(3226, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// There are 688 Fridays the 13th in every 400 years, and the third
+		// in 2026 is in November.
+		{"0 0 13 * FRI", 688*10 + 2, time.Date(6026, 11, 13, 0, 0, 0, 0, time.UTC)},
+	} {
+		if got := mustParse(t, tt.expr).NextAfterSkipping(from, tt.n); !got.Equal(tt.want) {
+			t.Errorf("%q.NextAfterSkipping(%s, %d) = %s; want %s", tt.expr, from, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"sort"
+	"time"
+)
+
+// SortByNext sorts schedules in place by the next time after from at which
+// each fires, as given by Next, soonest first. The sort is stable, so
+// schedules which next fire at the same time keep their order, and
+// schedules which never fire come last, also in their original order.
+//
+// Each schedule's next time is computed once. To sort other values by the
+// next times of their schedules, such as jobs, use NextTimes.
+//
+// SortByNext panics if any schedule is not valid.
+func SortByNext(schedules []Schedule, from time.Time) {
+	sort.Stable(byNext{schedules, NextTimes(schedules, from)})
+}
+
+// NextTimes returns the next time after from at which each of schedules
+// fires, as given by Next, or the zero Time for a schedule which never
+// fires. NextTimes panics if any schedule is not valid.
+func NextTimes(schedules []Schedule, from time.Time) []time.Time {
+	times := make([]time.Time, len(schedules))
+	for i, s := range schedules {
+		if err := s.Validate(); err != nil {
+			panic("NextTimes() called with invalid schedule: " + err.Error())
+		}
+		times[i] = s.Next(from)
+	}
+	return times
+}
+
+// byNext sorts schedules by their next times, with zero times last.
+type byNext struct {
+	schedules []Schedule
+	times     []time.Time
+}
+
+func (b byNext) Len() int { return len(b.schedules) }
+
+func (b byNext) Less(i, j int) bool {
+	ti, tj := b.times[i], b.times[j]
+	if ti.IsZero() || tj.IsZero() {
+		return !ti.IsZero() && tj.IsZero()
+	}
+	return ti.Before(tj)
+}
+
+func (b byNext) Swap(i, j int) {
+	b.schedules[i], b.schedules[j] = b.schedules[j], b.schedules[i]
+	b.times[i], b.times[j] = b.times[j], b.times[i]
+}
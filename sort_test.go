@@ -0,0 +1,50 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSortByNext(t *testing.T) {
+	exprs := []string{
+		"0 0 30 2 *", // never fires
+		"0 12 * * *",
+		"*/15 * * * *",
+		"0 0 31 4 *", // never fires
+		"30 10 * * *",
+		"0,30 10 * * *",
+		"0 11 * * *",
+		"30 10 * * *",
+	}
+	var schedules []Schedule
+	for _, expr := range exprs {
+		schedules = append(schedules, mustParse(t, expr))
+	}
+	from := time.Date(2026, 3, 2, 10, 20, 0, 0, time.UTC)
+
+	times := NextTimes(schedules, from)
+	if !times[0].IsZero() || !times[1].Equal(time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("NextTimes gave %v", times)
+	}
+
+	SortByNext(schedules, from)
+	var got []string
+	for _, s := range schedules {
+		got = append(got, s.String())
+	}
+	want := []string{
+		"*/15 * * * *",
+		"30 10 * * *",
+		"0,30 10 * * *",
+		"30 10 * * *",
+		"0 11 * * *",
+		"0 12 * * *",
+		"0 0 30 FEB *",
+		"0 0 31 APR *",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SortByNext: (-want, +got)\n%s", diff)
+	}
+}
@@ -0,0 +1,96 @@
+package cron
+
+// A SpreadReport describes how evenly an H expression spreads a set of keys
+// over the schedules it can produce. It is returned by HashSpread.
+type SpreadReport struct {
+	// Schedules holds the schedule for each key, in the order of the keys.
+	Schedules []Schedule
+	// Slots is the number of distinct schedules which the expression can
+	// produce. For example, "H H * * *" has 1440 slots, one for each
+	// minute of the day.
+	Slots int
+	// Collisions holds the groups of keys which share a schedule, in the
+	// order of each group's first key. Keys which have a schedule to
+	// themselves are not included.
+	Collisions [][]string
+	// MaxPerSlot is the largest number of keys which share a schedule.
+	MaxPerSlot int
+	// ChiSquared is Pearson's chi-squared statistic for the number of keys
+	// in each slot, compared with a uniform distribution. It has Slots-1
+	// degrees of freedom, so for keys which are spread well, it is close
+	// to Slots-1.
+	ChiSquared float64
+}
+
+// HashSpread parses expr with ParseH for each of keys, using the seed given
+// by KeySeed, and reports how the keys are spread over the possible
+// schedules. It returns an error if expr is invalid.
+//
+// Keys which collide fire at the same times. With n keys and k slots, some
+// collisions are expected when n is more than about the square root of k
+// (as in the birthday problem), but a MaxPerSlot far above n/k, or a
+// ChiSquared far above Slots-1, means that the keys are bunched together.
+func HashSpread(expr string, keys []string) (SpreadReport, error) {
+	var c countingRNG
+	if _, err := parseH(expr, &c); err != nil {
+		return SpreadReport{}, err
+	}
+	r := SpreadReport{
+		Schedules: make([]Schedule, len(keys)),
+		Slots:     c.outcomes(),
+	}
+	bySchedule := make(map[Schedule][]string)
+	var order []Schedule
+	for i, key := range keys {
+		s, err := ParseH(expr, KeySeed(key))
+		if err != nil {
+			return SpreadReport{}, err
+		}
+		r.Schedules[i] = s
+		if _, ok := bySchedule[s]; !ok {
+			order = append(order, s)
+		}
+		bySchedule[s] = append(bySchedule[s], key)
+	}
+	if len(keys) == 0 {
+		return r, nil
+	}
+
+	// With n keys and an expected count of e = n/k in each of the k
+	// slots, the statistic is the sum over the slots of (count-e)²/e,
+	// which is (sum of count²)/e - n.
+	expected := float64(len(keys)) / float64(r.Slots)
+	var sumSquares float64
+	for _, s := range order {
+		group := bySchedule[s]
+		if len(group) > 1 {
+			r.Collisions = append(r.Collisions, group)
+		}
+		if len(group) > r.MaxPerSlot {
+			r.MaxPerSlot = len(group)
+		}
+		sumSquares += float64(len(group) * len(group))
+	}
+	r.ChiSquared = sumSquares/expected - float64(len(keys))
+	return r, nil
+}
+
+// A countingRNG is an rng which always chooses 0 and records the number
+// of choices it was offered.
+type countingRNG struct {
+	ns []int
+}
+
+func (r *countingRNG) Intn(n int) int {
+	r.ns = append(r.ns, n)
+	return 0
+}
+
+// outcomes returns the number of distinct sequences of choices.
+func (r *countingRNG) outcomes() int {
+	product := 1
+	for _, n := range r.ns {
+		product *= n
+	}
+	return product
+}
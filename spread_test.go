@@ -0,0 +1,89 @@
+package cron
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHashSpread(t *testing.T) {
+	for _, tt := range []struct {
+		expr  string
+		slots int
+	}{
+		{"H H * * *", 1440},
+		{"H/15 * * * *", 15},
+		{"@monthly", 60 * 24 * 28},
+		{"0 0 * * *", 1},
+	} {
+		r, err := HashSpread(tt.expr, nil)
+		if err != nil {
+			t.Fatalf("HashSpread(%q): %s", tt.expr, err)
+		}
+		if r.Slots != tt.slots {
+			t.Errorf("HashSpread(%q): got %d slots; want %d", tt.expr, r.Slots, tt.slots)
+		}
+	}
+
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	r, err := HashSpread("H * * * *", keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := make(map[Schedule][]string)
+	for i, key := range keys {
+		if want, _ := ParseH("H * * * *", KeySeed(key)); r.Schedules[i] != want {
+			t.Errorf("schedule for %q is %q; want %q", key, r.Schedules[i], want)
+		}
+		groups[r.Schedules[i]] = append(groups[r.Schedules[i]], key)
+	}
+	max := 0
+	var sumSquares float64
+	for _, g := range groups {
+		if len(g) > max {
+			max = len(g)
+		}
+		sumSquares += float64(len(g) * len(g))
+	}
+	if r.MaxPerSlot != max {
+		t.Errorf("MaxPerSlot = %d; want %d", r.MaxPerSlot, max)
+	}
+	if want := sumSquares*60/6 - 6; math.Abs(r.ChiSquared-want) > 1e-9 {
+		t.Errorf("ChiSquared = %g; want %g", r.ChiSquared, want)
+	}
+
+	// Every key lands in the only slot.
+	r, err = HashSpread("0 0 * * *", keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([][]string{keys}, r.Collisions); diff != "" {
+		t.Errorf("Collisions: (-want, +got)\n%s", diff)
+	}
+	if r.MaxPerSlot != 6 || r.ChiSquared != 0 {
+		t.Errorf("got MaxPerSlot %d, ChiSquared %g; want 6, 0", r.MaxPerSlot, r.ChiSquared)
+	}
+
+	if _, err := HashSpread("H H * *", keys); err == nil {
+		t.Error("HashSpread accepted an invalid expression")
+	}
+}
+
+func TestHashSpreadNightlyJobs(t *testing.T) {
+	keys := make([]string, 2000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("job-%d", i)
+	}
+	r, err := HashSpread("H H * * *", keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// With 2000 keys in 1440 slots, a uniform spread has a chi-squared
+	// statistic near 1439 (with a standard deviation of about 54) and no
+	// more than 6 or so keys in a slot.
+	if r.ChiSquared > 1439+5*54 || r.MaxPerSlot > 8 {
+		t.Errorf("keys are not spread evenly: ChiSquared = %g, MaxPerSlot = %d", r.ChiSquared, r.MaxPerSlot)
+	}
+}
@@ -0,0 +1,41 @@
+package cron
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer. A Schedule is stored as the text of its
+// cron expression (see String). The zero Schedule is stored as NULL.
+func (s Schedule) Value() (driver.Value, error) {
+	if s == (Schedule{}) {
+		return nil, nil
+	}
+	if err := s.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot store invalid cron schedule: %w", err)
+	}
+	return s.String(), nil
+}
+
+// Scan implements sql.Scanner. It parses a cron expression stored as text
+// using Parse. NULL is scanned as the zero Schedule.
+func (s *Schedule) Scan(src interface{}) error {
+	var expr string
+	switch src := src.(type) {
+	case nil:
+		*s = Schedule{}
+		return nil
+	case string:
+		expr = src
+	case []byte:
+		expr = string(src)
+	default:
+		return fmt.Errorf("cannot scan %T into a cron schedule", src)
+	}
+	s1, err := Parse(expr)
+	if err != nil {
+		return err
+	}
+	*s = s1
+	return nil
+}
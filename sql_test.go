@@ -0,0 +1,74 @@
+package cron
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+)
+
+var (
+	_ driver.Valuer = Schedule{}
+	_ sql.Scanner   = (*Schedule)(nil)
+)
+
+func TestValueScan(t *testing.T) {
+	for _, expr := range []string{
+		"* * * * *",
+		"*/15 0-6 1 * *",
+		"0 3 * * Wed",
+		"1,3-5,10-45/10,58 21-3 * JAN-MAR MON,FRI",
+	} {
+		s := mustParse(t, expr)
+		v, err := s.Value()
+		if err != nil {
+			t.Fatalf("Value(%q): %s", expr, err)
+		}
+		if _, ok := v.(string); !ok {
+			t.Fatalf("Value(%q): got %T; want string", expr, v)
+		}
+		var s1 Schedule
+		if err := s1.Scan(v); err != nil {
+			t.Fatalf("Scan(%q): %s", v, err)
+		}
+		if s1 != s {
+			t.Errorf("Value/Scan round trip of %q (stored as %q) gave a different schedule", expr, v)
+		}
+		var s2 Schedule
+		if err := s2.Scan([]byte(v.(string))); err != nil {
+			t.Fatalf("Scan([]byte(%q)): %s", v, err)
+		}
+		if s2 != s {
+			t.Errorf("Scan([]byte(%q)) gave a different schedule", v)
+		}
+	}
+}
+
+func TestValueScanNull(t *testing.T) {
+	v, err := Schedule{}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != nil {
+		t.Fatalf("zero Schedule: got Value %v; want nil", v)
+	}
+	s := mustParse(t, "* * * * *")
+	if err := s.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if s != (Schedule{}) {
+		t.Fatalf("Scan(nil) gave %v; want zero Schedule", s)
+	}
+}
+
+func TestScanFail(t *testing.T) {
+	var s Schedule
+	for _, src := range []interface{}{
+		"* * *",
+		[]byte("60 * * * *"),
+		42,
+	} {
+		if err := s.Scan(src); err == nil {
+			t.Errorf("Scan(%#v) succeeded; want error", src)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"expvar"
+	"fmt"
+	"time"
+)
+
+// RunnerStats is a snapshot of the state of a Runner.
+type RunnerStats struct {
+	Running bool      // whether the Runner is running
+	Active  int       // the number of job runs in progress
+	Jobs    []JobInfo // in the order they were added
+}
+
+// Stats returns a snapshot of the state of r.
+func (r *Runner) Stats() RunnerStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := RunnerStats{Running: r.running, Active: r.active, Jobs: make([]JobInfo, len(r.entries))}
+	for i, e := range r.entries {
+		st.Jobs[i] = e.info()
+	}
+	return st
+}
+
+// Publish publishes r's Stats as an expvar variable with the given name,
+// so that it appears at /debug/vars. Like expvar.Publish, Publish panics if
+// the name is already registered. The variable is a JSON object such as
+//
+//	{
+//		"running": true,
+//		"active": 1,
+//		"jobs": [
+//			{"id": 1, "name": "backup", "recurrence": "0 3 * * *",
+//			 "prev": "2026-03-01T03:00:00Z", "next": "2026-03-02T03:00:00Z", "active": 1}
+//		]
+//	}
+//
+// in which times are omitted if they are zero, as is the recurrence of a job
+// without one. A job also has "skipUntil" if it is skipping occurrences and
+// "paused": true if it is paused.
+func (r *Runner) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} { return r.Stats().expvarValue() }))
+}
+
+// jobJSON is the JSON form of a JobInfo, used by Publish and AdminHandler.
+type jobJSON struct {
+	ID         JobID  `json:"id"`
+	Name       string `json:"name,omitempty"`
+	Recurrence string `json:"recurrence,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Active     int    `json:"active"`
+	SkipUntil  string `json:"skipUntil,omitempty"`
+	Paused     bool   `json:"paused,omitempty"`
+}
+
+func newJobJSON(info JobInfo) jobJSON {
+	var rec string
+	if info.Recurrence != nil {
+		rec = fmt.Sprint(info.Recurrence)
+	}
+	return jobJSON{
+		ID:         info.ID,
+		Name:       info.Name,
+		Recurrence: rec,
+		Prev:       formatOptionalTime(info.Prev),
+		Next:       formatOptionalTime(info.Next),
+		Active:     info.Active,
+		SkipUntil:  formatOptionalTime(info.SkipUntil),
+		Paused:     info.Paused,
+	}
+}
+
+func (st RunnerStats) expvarValue() interface{} {
+	jobs := make([]jobJSON, len(st.Jobs))
+	for i, info := range st.Jobs {
+		jobs[i] = newJobJSON(info)
+	}
+	return struct {
+		Running bool      `json:"running"`
+		Active  int       `json:"active"`
+		Jobs    []jobJSON `json:"jobs"`
+	}{st.Running, st.Active, jobs}
+}
+
+// formatOptionalTime formats t in RFC 3339 format, or as "" if t is zero.
+func formatOptionalTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,64 @@
+package cron
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRunnerStats(t *testing.T) {
+	r := NewRunner()
+	release := make(chan struct{})
+	defer close(release)
+	started := make(chan struct{})
+	r.AddFunc(At(time.Now().Add(10*time.Millisecond)), func() {
+		close(started)
+		<-release
+	}, WithName("blocked"))
+	daily := mustParse(t, "0 3 * * *")
+	r.AddFunc(daily, func() {})
+
+	if st := r.Stats(); st.Running || st.Active != 0 || len(st.Jobs) != 2 {
+		t.Errorf("before Start, Stats() = %+v", st)
+	}
+	r.Start()
+	defer r.Stop()
+	<-started
+	st := r.Stats()
+	if !st.Running || st.Active != 1 || len(st.Jobs) != 2 {
+		t.Fatalf("while running, Stats() = %+v", st)
+	}
+	if j := st.Jobs[0]; j.Name != "blocked" || j.Active != 1 || j.Prev.IsZero() || !j.Next.IsZero() {
+		t.Errorf("blocked job: got %+v", j)
+	}
+
+	name := fmt.Sprintf("cron_test_runner_%d", time.Now().UnixNano()) // unique across -count runs
+	r.Publish(name)
+	var got struct {
+		Running bool
+		Active  int
+		Jobs    []map[string]interface{}
+	}
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Running || got.Active != 1 || len(got.Jobs) != 2 {
+		t.Fatalf("expvar value: got %+v", got)
+	}
+	want := map[string]interface{}{
+		"id":         float64(st.Jobs[1].ID),
+		"recurrence": "0 3 * * *",
+		"next":       st.Jobs[1].Next.Format(time.RFC3339Nano),
+		"active":     float64(0),
+	}
+	if diff := cmp.Diff(got.Jobs[1], want); diff != "" {
+		t.Errorf("expvar job (-got, +want):\n%s", diff)
+	}
+	if got.Jobs[0]["name"] != "blocked" || got.Jobs[0]["prev"] == nil || got.Jobs[0]["next"] != nil {
+		t.Errorf("expvar blocked job: got %v", got.Jobs[0])
+	}
+}
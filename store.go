@@ -0,0 +1,157 @@
+package cron
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// A Store records when jobs last ran, so that a Runner knows what already
+// ran before it was restarted (see WithStore). Jobs are identified by
+// their names (see WithName).
+//
+// Store implementations must be safe for concurrent use.
+type Store interface {
+	// LoadLastRun returns the occurrence at which the job with the given
+	// name last ran, or the zero Time if it is not known.
+	LoadLastRun(name string) (time.Time, error)
+	// SaveLastRun records that the job with the given name ran at the
+	// occurrence t.
+	SaveLastRun(name string, t time.Time) error
+}
+
+// WithStore makes the Runner record in s when each named job runs, and
+// consult s for when the job last ran as it is added to the Runner, so
+// that it is known after the program restarts. Jobs without names are not
+// recorded.
+//
+// The Runner reports errors from s to the error handler (see
+// WithErrorHandler).
+func WithStore(s Store) RunnerOption {
+	return func(r *Runner) { r.store = s }
+}
+
+// A MemoryStore is a Store which keeps the times in memory. It is useful
+// in tests and for Runners which are restarted within a process.
+type MemoryStore struct {
+	mu    sync.Mutex
+	times map[string]time.Time
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{times: make(map[string]time.Time)}
+}
+
+// LoadLastRun implements Store.
+func (s *MemoryStore) LoadLastRun(name string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.times[name], nil
+}
+
+// SaveLastRun implements Store.
+func (s *MemoryStore) SaveLastRun(name string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.times[name] = t
+	return nil
+}
+
+// A FileStore is a Store which keeps the times in a JSON file. The file
+// maps job names to times in RFC 3339 format. It is read once, when a time
+// is first loaded or saved, and rewritten in full (by replacing it) each
+// time a time is saved, so it should not be shared by several FileStores.
+type FileStore struct {
+	path string
+
+	mu    sync.Mutex
+	times map[string]time.Time // nil until the file is read
+}
+
+// NewFileStore returns a FileStore which keeps its times in the file at
+// path. The file need not exist yet.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// LoadLastRun implements Store.
+func (s *FileStore) LoadLastRun(name string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.read(); err != nil {
+		return time.Time{}, err
+	}
+	return s.times[name], nil
+}
+
+// SaveLastRun implements Store.
+func (s *FileStore) SaveLastRun(name string, t time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.read(); err != nil {
+		return err
+	}
+	old, ok := s.times[name]
+	s.times[name] = t
+	if err := s.write(); err != nil {
+		if ok {
+			s.times[name] = old
+		} else {
+			delete(s.times, name)
+		}
+		return err
+	}
+	return nil
+}
+
+// read reads the file, if it has not been read yet. The caller must hold
+// s.mu.
+func (s *FileStore) read() error {
+	if s.times != nil {
+		return nil
+	}
+	times := make(map[string]time.Time)
+	b, err := ioutil.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &times); err != nil {
+			return fmt.Errorf("reading %s: %s", s.path, err)
+		}
+	}
+	s.times = times
+	return nil
+}
+
+// write replaces the file with the current times. The caller must hold
+// s.mu.
+func (s *FileStore) write() error {
+	b, err := json.MarshalIndent(s.times, "", "\t")
+	if err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), s.path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testStore(t *testing.T, s Store) {
+	t.Helper()
+	t1 := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	if got, err := s.LoadLastRun("backup"); err != nil || !got.IsZero() {
+		t.Fatalf("LoadLastRun of unknown job = %s, %v; want zero time", got, err)
+	}
+	for _, name := range []string{"backup", "report"} {
+		if err := s.SaveLastRun(name, t1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.SaveLastRun("backup", t1.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.LoadLastRun("backup"); err != nil || !got.Equal(t1.AddDate(0, 0, 1)) {
+		t.Errorf("LoadLastRun(backup) = %s, %v; want %s", got, err, t1.AddDate(0, 0, 1))
+	}
+	if got, err := s.LoadLastRun("report"); err != nil || !got.Equal(t1) {
+		t.Errorf("LoadLastRun(report) = %s, %v; want %s", got, err, t1)
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cron")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "lastrun.json")
+	testStore(t, NewFileStore(path))
+
+	// A new FileStore reads what the first one wrote.
+	got, err := NewFileStore(path).LoadLastRun("report")
+	if want := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC); err != nil || !got.Equal(want) {
+		t.Errorf("after reopening, LoadLastRun(report) = %s, %v; want %s", got, err, want)
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("directory has %d files; want only the store", len(files))
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := ioutil.WriteFile(bad, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFileStore(bad).LoadLastRun("x"); err == nil {
+		t.Error("LoadLastRun from corrupt file succeeded")
+	}
+}
+
+type failingStore struct{}
+
+func (failingStore) LoadLastRun(string) (time.Time, error) {
+	return time.Time{}, errors.New("load failed")
+}
+func (failingStore) SaveLastRun(string, time.Time) error { return errors.New("save failed") }
+
+func TestRunnerStore(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	store := NewMemoryStore()
+	r := NewRunner(WithClock(clock), WithStore(store))
+	r.AddFunc(mustParse(t, "0 3 * * *"), func() {}, WithName("backup"))
+	r.AddFunc(mustParse(t, "0 3 * * *"), func() {}) // not recorded
+	r.Start()
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(3 * time.Hour)
+	waitFor(t, "run", func() bool { return !r.Entries()[1].Prev.IsZero() })
+	r.Stop()
+	r.Wait(context.Background())
+	ran := time.Date(2026, 1, 1, 3, 0, 0, 0, time.UTC)
+	if got, _ := store.LoadLastRun("backup"); !got.Equal(ran) {
+		t.Errorf("store has last run %s; want %s", got, ran)
+	}
+	if got, _ := store.LoadLastRun(""); !got.IsZero() {
+		t.Errorf("store has last run %s for unnamed job", got)
+	}
+
+	// A new Runner with the same store knows when the job last ran.
+	r = NewRunner(WithStore(store))
+	r.AddFunc(mustParse(t, "0 3 * * *"), func() {}, WithName("backup"))
+	if got := r.Entries()[0].Prev; !got.Equal(ran) {
+		t.Errorf("new Runner has Prev %s; want %s", got, ran)
+	}
+
+	// Errors from the Store go to the error handler.
+	errs := make(chan error, 2)
+	r = NewRunner(WithStore(failingStore{}), WithErrorHandler(func(_ JobID, err error) { errs <- err }))
+	r.AddFunc(At(time.Now().Add(10*time.Millisecond)), func() {}, WithName("job"))
+	r.Start()
+	defer r.Stop()
+	for _, want := range []string{"loading last run of job: load failed", "saving last run of job: save failed"} {
+		select {
+		case err := <-errs:
+			if err.Error() != want {
+				t.Errorf("error handler got %q; want %q", err, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for error %q", want)
+		}
+	}
+}
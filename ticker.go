@@ -0,0 +1,125 @@
+package cron
+
+import (
+	"sync"
+	"time"
+)
+
+// A Ticker delivers the times at which a Schedule fires on a channel, like a
+// time.Ticker. It is a lighter alternative to a Runner for a single
+// schedule. Use NewTicker to create a Ticker.
+type Ticker struct {
+	// C is the channel on which the times are delivered.
+	C <-chan time.Time
+
+	l *loop
+}
+
+// NewTicker returns a Ticker which sends each time at which s fires, in the
+// local time zone, on its channel. The Ticker sends the time at which s
+// fires (rather than the current time, as a time.Ticker does), once the
+// time is reached.
+//
+// Like a time.Ticker, the channel holds one time, and if the receiver has
+// not taken it when the next time is reached, the later time is dropped.
+// Times which are missed while the computer is asleep are skipped too.
+//
+// A Ticker for a schedule which never fires never sends. Call Stop to
+// release the Ticker's resources when it is no longer needed. NewTicker
+// panics if s is not valid.
+func NewTicker(s Schedule) *Ticker {
+	if err := s.Validate(); err != nil {
+		panic("NewTicker() called on invalid schedule: " + err.Error())
+	}
+	return newTicker(s, SystemClock)
+}
+
+func newTicker(r Recurrence, c Clock) *Ticker {
+	ch := make(chan time.Time, 1)
+	l := startLoop(r, c, func(t time.Time) {
+		select {
+		case ch <- t:
+		default:
+		}
+	})
+	return &Ticker{C: ch, l: l}
+}
+
+// Stop turns off the Ticker, after which no more times are sent. As with a
+// time.Ticker, Stop does not close the channel. It is safe to call Stop
+// more than once.
+func (t *Ticker) Stop() { t.l.halt() }
+
+// A FuncTimer calls a function each time a Schedule fires. It is the
+// analog of the time.Timer returned by time.AfterFunc, and a lighter
+// alternative to a Runner for a single job. Use Func to create a
+// FuncTimer.
+type FuncTimer struct {
+	l *loop
+}
+
+// Func calls f in its own goroutine each time s fires, in the local time
+// zone, until Stop is called on the returned FuncTimer. As with
+// time.AfterFunc, a call of f does not wait for the previous one to
+// return. Times which are missed while the computer is asleep are skipped.
+// Func panics if s is not valid.
+func Func(s Schedule, f func()) *FuncTimer {
+	if err := s.Validate(); err != nil {
+		panic("Func() called on invalid schedule: " + err.Error())
+	}
+	return newFuncTimer(s, SystemClock, f)
+}
+
+func newFuncTimer(r Recurrence, c Clock, f func()) *FuncTimer {
+	return &FuncTimer{l: startLoop(r, c, func(time.Time) { go f() })}
+}
+
+// Stop stops the FuncTimer, after which f is not called again. It does not
+// wait for a call of f which is running to return. It is safe to call Stop
+// more than once.
+func (t *FuncTimer) Stop() { t.l.halt() }
+
+// A loop waits for each occurrence of a Recurrence in turn, for a Ticker or
+// a FuncTimer.
+type loop struct {
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// startLoop starts a loop which calls fire with each occurrence of r after
+// the current time, according to c, when it is reached. Occurrences which
+// have passed before fire is called are skipped.
+func startLoop(r Recurrence, c Clock, fire func(time.Time)) *loop {
+	l := &loop{stop: make(chan struct{})}
+	go l.run(r, c, fire)
+	return l
+}
+
+func (l *loop) run(r Recurrence, c Clock, fire func(time.Time)) {
+	next := r.Next(c.Now())
+	for !next.IsZero() {
+		now := c.Now()
+		if now.Before(next) {
+			// As in Schedule.Wait, check the wall clock at least every
+			// maxWaitTimer, rather than trusting a long timer.
+			d := next.Sub(now)
+			if d > maxWaitTimer {
+				d = maxWaitTimer
+			}
+			timer := c.NewTimer(d)
+			select {
+			case <-timer.C():
+			case <-l.stop:
+				timer.Stop()
+				return
+			}
+			continue
+		}
+		fire(next)
+		next = r.Next(now)
+	}
+}
+
+func (l *loop) halt() {
+	l.stopOnce.Do(func() { close(l.stop) })
+}
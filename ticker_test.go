@@ -0,0 +1,120 @@
+package cron
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	ticker := newTicker(mustParse(t, "0 * * * *"), clock)
+	defer ticker.Stop()
+
+	expect := func(want time.Time) {
+		t.Helper()
+		select {
+		case got := <-ticker.C:
+			if !got.Equal(want) {
+				t.Errorf("got tick at %s; want %s", got, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for tick at %s", want)
+		}
+	}
+	advance := func(d time.Duration) {
+		t.Helper()
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.advance(d)
+	}
+
+	advance(time.Hour)
+	expect(start.Add(time.Hour))
+
+	// The channel holds one tick; the 03:00 tick is dropped.
+	advance(time.Hour)
+	advance(time.Hour)
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	expect(start.Add(2 * time.Hour))
+
+	// Ticks missed by a late timer are skipped.
+	advance(150 * time.Minute)
+	expect(start.Add(4 * time.Hour))
+	advance(30 * time.Minute)
+	expect(start.Add(6 * time.Hour))
+
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	ticker.Stop()
+	ticker.Stop()
+	waitFor(t, "timer to stop", func() bool { return clock.pending() == 0 })
+	select {
+	case tick := <-ticker.C:
+		t.Errorf("got tick at %s after Stop", tick)
+	default:
+	}
+}
+
+func TestTickerLongWait(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	ticker := newTicker(mustParse(t, "0 0 29 2 *"), clock)
+	defer ticker.Stop()
+
+	// The wait of more than two years is made of timers of at most
+	// maxWaitTimer, so a change to the clock is noticed.
+	for i := 0; i < 3; i++ {
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.mu.Lock()
+		d := clock.timers[len(clock.timers)-1].when.Sub(clock.now)
+		clock.mu.Unlock()
+		if d > maxWaitTimer {
+			t.Fatalf("timer set for %s; want at most %s", d, maxWaitTimer)
+		}
+		clock.advance(d)
+	}
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC).Sub(clock.Now()))
+	select {
+	case got := <-ticker.C:
+		if want := time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+			t.Errorf("got tick at %s; want %s", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for tick")
+	}
+}
+
+func TestTickerNeverFires(t *testing.T) {
+	ticker := NewTicker(mustParse(t, "0 0 30 2 *"))
+	ticker.Stop()
+	select {
+	case tick := <-ticker.C:
+		t.Errorf("got tick at %s", tick)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestFunc(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	var calls int32
+	ft := newFuncTimer(mustParse(t, "*/10 * * * *"), clock, func() { atomic.AddInt32(&calls, 1) })
+	for i := int32(1); i <= 3; i++ {
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.advance(10 * time.Minute)
+		waitFor(t, "call", func() bool { return atomic.LoadInt32(&calls) == i })
+	}
+
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	ft.Stop()
+	waitFor(t, "timer to stop", func() bool { return clock.pending() == 0 })
+	clock.advance(time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	if n := atomic.LoadInt32(&calls); n != 3 {
+		t.Errorf("got %d calls; want 3", n)
+	}
+
+	// A schedule which never fires is fine.
+	Func(mustParse(t, "0 0 30 2 *"), func() { t.Error("f called") }).Stop()
+}
@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// A Tracer is notified of each run of a Runner's jobs, typically in order to
+// record it as a span in a distributed tracing system. For example, a Tracer
+// for OpenTelemetry could be written as
+//
+//	func (t otelTracer) StartRun(ctx context.Context, run cron.RunInfo) (context.Context, func(error)) {
+//		ctx, span := t.tracer.Start(ctx, run.Name, trace.WithTimestamp(run.Start))
+//		span.SetAttributes(
+//			attribute.Int64("cron.job.id", int64(run.ID)),
+//			attribute.String("cron.schedule", fmt.Sprint(run.Recurrence)),
+//			attribute.String("cron.scheduled", run.Scheduled.Format(time.RFC3339Nano)),
+//		)
+//		return ctx, func(err error) {
+//			if err != nil {
+//				span.RecordError(err)
+//				span.SetStatus(codes.Error, err.Error())
+//			}
+//			span.End()
+//		}
+//	}
+type Tracer interface {
+	// StartRun is called as a run of a job starts. The job is given the
+	// returned context, so StartRun may use it to carry a span. The
+	// Runner calls the returned function with the job's error, if any,
+	// when the run finishes.
+	StartRun(ctx context.Context, run RunInfo) (context.Context, func(error))
+}
+
+// RunInfo describes a run of a job, for a Tracer.
+type RunInfo struct {
+	ID         JobID
+	Name       string // the job's name, if it has one (see WithName)
+	Recurrence Recurrence
+	Scheduled  time.Time // the occurrence being run
+	Start      time.Time // when the run actually started
+}
+
+// WithTracer makes the Runner notify t of each run of a job.
+func WithTracer(t Tracer) RunnerOption {
+	return func(r *Runner) { r.tracer = t }
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type spanKey struct{}
+
+type testTracer struct {
+	runs chan RunInfo
+	errs chan error
+}
+
+func (tr testTracer) StartRun(ctx context.Context, run RunInfo) (context.Context, func(error)) {
+	tr.runs <- run
+	return context.WithValue(ctx, spanKey{}, run.ID), func(err error) { tr.errs <- err }
+}
+
+func TestRunnerTracer(t *testing.T) {
+	tr := testTracer{runs: make(chan RunInfo, 1), errs: make(chan error, 1)}
+	r := NewRunner(WithTracer(tr))
+	at := time.Now().Add(10 * time.Millisecond)
+	rec := At(at)
+	var id JobID
+	id = r.Add(rec, func(ctx context.Context) error {
+		if got := ctx.Value(spanKey{}); got != id {
+			t.Errorf("job context has span for %v; want %v", got, id)
+		}
+		return errors.New("failed")
+	}, WithName("traced"))
+	r.Start()
+	defer r.Stop()
+
+	var run RunInfo
+	select {
+	case run = <-tr.runs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("StartRun was not called")
+	}
+	if run.ID != id || run.Name != "traced" || run.Recurrence != rec || !run.Scheduled.Equal(at) {
+		t.Errorf("StartRun got %+v", run)
+	}
+	if run.Start.Before(run.Scheduled) {
+		t.Errorf("run started at %s, before its occurrence %s", run.Start, run.Scheduled)
+	}
+	if err := <-tr.errs; err == nil || err.Error() != "failed" {
+		t.Errorf("end function got %v; want failed", err)
+	}
+}
@@ -0,0 +1,123 @@
+package cron
+
+import "time"
+
+// NextUTC is like Next, but it computes in UTC and returns a UTC time
+// regardless of t's location. It avoids the calendar computations of the
+// time package, so it is considerably faster than Next for most locations.
+// (Next uses the same method when t is in UTC.)
+//
+// Like Next, NextUTC returns the zero Time if s never fires, and panics if s
+// is not valid.
+func (s Schedule) NextUTC(t time.Time) time.Time {
+	if err := s.Validate(); err != nil {
+		panic("Next() called on invalid schedule: " + err.Error())
+	}
+	if s.normalize() == (Schedule{}) {
+		return time.Time{}
+	}
+	return s.nextUTC(t)
+}
+
+const minutesPerDay = 24 * 60
+
+func (s Schedule) nextUTC(t time.Time) time.Time {
+	// Start at the earliest possible subsequent minute.
+	m := floorDiv(t.Unix(), 60) + 1
+	days := floorDiv(m, minutesPerDay)
+	minOfDay := int(m - days*minutesPerDay)
+	f := s.fieldBits()
+	for {
+		year, month, day := civilDate(days)
+		mo, ok := nextBit(f[3], month-1)
+		if !ok {
+			mo, _ = nextBit(f[3], 0)
+			days, minOfDay = daysSinceEpoch(year+1, mo+1, 1), 0
+			continue
+		}
+		if mo != month-1 {
+			days, minOfDay = daysSinceEpoch(year, mo+1, 1), 0
+			continue
+		}
+		weekday := (days + 4) % 7 // 1970-01-01 was a Thursday
+		if weekday < 0 {
+			weekday += 7
+		}
+		d, ok := nextDay(f[2], f[4], year, time.Month(month), day, time.Weekday(weekday))
+		if !ok {
+			days, minOfDay = daysSinceEpoch(year, month+1, 1), 0
+			continue
+		}
+		if d != day {
+			days, minOfDay = days+int64(d-day), 0
+		}
+		hour, min := minOfDay/60, minOfDay%60
+		h, ok := nextBit(f[1], hour)
+		if !ok {
+			days, minOfDay = days+1, 0
+			continue
+		}
+		if h != hour {
+			hour, min = h, 0
+		}
+		mi, ok := nextBit(f[0], min)
+		if !ok {
+			if minOfDay = (hour + 1) * 60; minOfDay == minutesPerDay {
+				days, minOfDay = days+1, 0
+			}
+			continue
+		}
+		return time.Unix((days*minutesPerDay+int64(hour*60+mi))*60, 0).UTC()
+	}
+}
+
+// floorDiv returns x/y rounded toward negative infinity.
+func floorDiv(x, y int64) int64 {
+	q := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		q--
+	}
+	return q
+}
+
+// civilDate returns the proleptic Gregorian date that is the given number of
+// days after 1970-01-01. The algorithm is from
+// http://howardhinnant.github.io/date_algorithms.html.
+func civilDate(days int64) (year, month, day int) {
+	z := days + 719468 // days since 0000-03-01
+	era := floorDiv(z, 146097)
+	doe := z - era*146097                                  // [0, 146096]
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // [0, 399]
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               // [0, 365]
+	mp := (5*doy + 2) / 153                                // [0, 11], starting in March
+	day = int(doy - (153*mp+2)/5 + 1)
+	month = int(mp + 3)
+	if month > 12 {
+		month -= 12
+	}
+	year = int(yoe + era*400)
+	if month <= 2 {
+		year++
+	}
+	return year, month, day
+}
+
+// daysSinceEpoch returns the number of days from 1970-01-01 to the given
+// proleptic Gregorian date. As with time.Date, month may be 13 (meaning
+// January of the following year).
+func daysSinceEpoch(year, month, day int) int64 {
+	if month > 12 {
+		year++
+		month -= 12
+	}
+	y := int64(year)
+	if month <= 2 {
+		y--
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400
+	mp := int64((month + 9) % 12)
+	doy := (153*mp+2)/5 + int64(day) - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
@@ -0,0 +1,52 @@
+package cron
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestNextUTC(t *testing.T) {
+	exprs := []string{
+		"* * * * *",
+		"*/7 3-5 * * *",
+		"0 0 29 2 *",
+		"30 23 31 * *",
+		"0 12 1-7 * MON",
+		"59 23 31 12 *",
+		"0 0 * * SUN",
+		"15 */5 13 * FRI",
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, expr := range exprs {
+		s := mustParse(t, expr)
+		for i := 0; i < 200; i++ {
+			// Times from 1900 to 2100, at any second.
+			tm := time.Unix(r.Int63n(200*365*24*60*60)-70*365*24*60*60, r.Int63n(1e9))
+			want := s.next(tm.UTC())
+			if got := s.NextUTC(tm); !got.Equal(want) || got.Location() != time.UTC {
+				t.Errorf("Parse(%q).NextUTC(%s) = %s; want %s", expr, tm.UTC(), got, want)
+			}
+		}
+	}
+
+	ny := mustLoadLocation(t, "America/New_York")
+	tm := time.Date(2026, 6, 1, 12, 0, 0, 0, ny)
+	got := mustParse(t, "0 9 * * *").NextUTC(tm)
+	if want := time.Date(2026, 6, 2, 9, 0, 0, 0, time.UTC); !got.Equal(want) || got.Location() != time.UTC {
+		t.Errorf("NextUTC(%s) = %s; want %s", tm, got, want)
+	}
+}
+
+func TestCivilDate(t *testing.T) {
+	for days := int64(-1000000); days < 1000000; days += 997 {
+		tm := time.Unix(days*24*60*60, 0).UTC()
+		year, month, day := civilDate(days)
+		if year != tm.Year() || month != int(tm.Month()) || day != tm.Day() {
+			t.Fatalf("civilDate(%d) = %d-%d-%d; want %s", days, year, month, day, tm.Format("2006-01-02"))
+		}
+		if got := daysSinceEpoch(year, month, day); got != days {
+			t.Fatalf("daysSinceEpoch(%d, %d, %d) = %d; want %d", year, month, day, got, days)
+		}
+	}
+}
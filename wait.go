@@ -0,0 +1,55 @@
+package cron
+
+import (
+	"context"
+	"time"
+)
+
+// maxWaitTimer is the longest a single timer runs in Schedule.Wait, a
+// Ticker, a FuncTimer, or a Runner. Timers measure elapsed time, which can drift from
+// the wall clock over a long wait (as when the computer is asleep or the
+// clock is set), so they check the wall clock at least this often.
+const maxWaitTimer = time.Minute
+
+// Wait blocks until the next time after from at which s fires, as given by
+// Next, and returns that time. If ctx is done first, Wait returns the zero
+// Time and ctx.Err(). A schedule which never fires waits for ctx.
+//
+// Wait compares the time with the wall clock, rather than sleeping for the
+// duration until the next time, so it returns at the right time (or within
+// a minute of it) even if the wall clock is changed or the computer sleeps
+// during a long wait. If the next time has already passed, Wait returns it
+// immediately.
+//
+// Wait panics if s is not valid.
+func (s Schedule) Wait(ctx context.Context, from time.Time) (time.Time, error) {
+	if err := s.Validate(); err != nil {
+		panic("Wait() called on invalid schedule: " + err.Error())
+	}
+	return wait(ctx, s, from, SystemClock)
+}
+
+// wait waits, according to c, for the next occurrence of r after from.
+func wait(ctx context.Context, r Recurrence, from time.Time, c Clock) (time.Time, error) {
+	next := r.Next(from)
+	if next.IsZero() {
+		<-ctx.Done()
+		return time.Time{}, ctx.Err()
+	}
+	for {
+		d := next.Sub(c.Now())
+		if d <= 0 {
+			return next, nil
+		}
+		if d > maxWaitTimer {
+			d = maxWaitTimer
+		}
+		timer := c.NewTimer(d)
+		select {
+		case <-timer.C():
+		case <-ctx.Done():
+			timer.Stop()
+			return time.Time{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWait(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := &manualClock{now: start}
+	hourly := mustParse(t, "0 * * * *")
+
+	type result struct {
+		t   time.Time
+		err error
+	}
+	waitAsync := func(ctx context.Context, r Recurrence, from time.Time) <-chan result {
+		ch := make(chan result, 1)
+		go func() {
+			t, err := wait(ctx, r, from, clock)
+			ch <- result{t, err}
+		}()
+		return ch
+	}
+	get := func(ch <-chan result) result {
+		t.Helper()
+		select {
+		case r := <-ch:
+			return r
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for wait to return")
+			panic("unreachable")
+		}
+	}
+
+	// The wait is made of timers of at most a minute.
+	ch := waitAsync(context.Background(), hourly, start)
+	for i := 0; i < 60; i++ {
+		waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+		clock.advance(time.Minute)
+	}
+	if r := get(ch); r.err != nil || !r.t.Equal(start.Add(time.Hour)) {
+		t.Errorf("wait returned %s, %v; want %s", r.t, r.err, start.Add(time.Hour))
+	}
+
+	// A jump in the clock is noticed when the next timer fires.
+	ch = waitAsync(context.Background(), hourly, clock.Now())
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	clock.advance(90 * time.Minute)
+	if r := get(ch); r.err != nil || !r.t.Equal(start.Add(2*time.Hour)) {
+		t.Errorf("wait returned %s, %v; want %s", r.t, r.err, start.Add(2*time.Hour))
+	}
+
+	// A time which has passed is returned immediately.
+	if r := get(waitAsync(context.Background(), hourly, start)); r.err != nil || !r.t.Equal(start.Add(time.Hour)) {
+		t.Errorf("wait returned %s, %v; want %s", r.t, r.err, start.Add(time.Hour))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch = waitAsync(ctx, hourly, clock.Now())
+	waitFor(t, "timer", func() bool { return clock.pending() == 1 })
+	cancel()
+	if r := get(ch); r.err != context.Canceled || !r.t.IsZero() {
+		t.Errorf("wait returned %s, %v; want context.Canceled", r.t, r.err)
+	}
+	if n := clock.pending(); n != 0 {
+		t.Errorf("%d timers pending after cancellation", n)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := mustParse(t, "0 0 30 2 *").Wait(ctx, time.Now()); err != context.DeadlineExceeded {
+		t.Errorf("Wait for a schedule which never fires returned %v; want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,100 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A Warning describes part of a cron expression which is valid but
+// probably does not mean what its author intended. Warnings are returned by
+// ParseWithDiagnostics.
+type Warning struct {
+	// Field is the name of the field containing the problem, such as
+	// "minute", or "" if the problem concerns the whole expression.
+	Field string
+	// Part is the element of the field's list with the problem, such as
+	// "*/90", or "" if the problem concerns the whole expression.
+	Part    string
+	Message string
+}
+
+func (w Warning) String() string {
+	if w.Field == "" {
+		return w.Message
+	}
+	return fmt.Sprintf("%s field %q: %s", w.Field, w.Part, w.Message)
+}
+
+// ParseWithDiagnostics is like Parse, but it also returns warnings about
+// suspicious constructs in expr which Parse accepts without comment:
+//
+//   - A step which is not smaller than its range, so that only the first
+//     value of the range is selected, as in "*/60" for minutes. Note that,
+//     unlike in some versions of cron, a step after a single value (as in
+//     "5/15") selects only that value.
+//   - An element of a list which also contains *, such as the 5 in "*,5",
+//     which has no effect.
+//   - A range which wraps around the end of the field, such as 22-2 for
+//     hours, meaning 22-23 and 0-2.
+//   - A schedule which never fires, such as "0 0 30 2 *".
+//
+// The warnings are in the order of the fields. If expr is not valid,
+// ParseWithDiagnostics returns the error given by Parse and no warnings.
+func ParseWithDiagnostics(expr string) (Schedule, []Warning, error) {
+	s, err := Parse(expr)
+	if err != nil {
+		return Schedule{}, nil, err
+	}
+	if strings.HasPrefix(expr, "@") {
+		return s, nil, nil
+	}
+	var warnings []Warning
+	for i, field := range strings.Fields(expr) {
+		parts := strings.Split(field, ",")
+		wildcard := false
+		for _, part := range parts {
+			if part == "*" {
+				wildcard = true
+			}
+		}
+		for _, part := range parts {
+			warn := func(format string, args ...interface{}) {
+				warnings = append(warnings, Warning{fieldNames[i], part, fmt.Sprintf(format, args...)})
+			}
+			if wildcard && part != "*" {
+				warn("the list contains *, so this has no effect")
+				continue
+			}
+			r, step := part, 1
+			if j := strings.Index(part, "/"); j >= 0 {
+				r = part[:j]
+				step, _ = strconv.Atoi(part[j+1:])
+			}
+			vals := mustParsePart(part, i).fieldValues(i)
+			if step > 1 && len(vals) == 1 {
+				warn("the step is not smaller than the range, so only %s is selected", formatList(vals, i, formatStyle{}))
+			}
+			if bounds := strings.SplitN(r, "-", 2); len(bounds) == 2 {
+				start, _ := parseSingleValue(bounds[0], i)
+				end, _ := parseSingleValue(bounds[1], i)
+				if start > end {
+					warn("the range wraps around the end of the field, selecting %s", formatList(vals, i, formatStyle{noWrap: true}))
+				}
+			}
+		}
+	}
+	if s.normalize() == (Schedule{}) {
+		warnings = append(warnings, Warning{Message: "the schedule never fires"})
+	}
+	return s, warnings, nil
+}
+
+// mustParsePart parses part, an element of a list in a valid expression.
+func mustParsePart(part string, fieldIndex int) Schedule {
+	s, _, err := parseSinglePart(part, fieldIndex, new(fixedRNG))
+	if err != nil {
+		panic("cron: " + err.Error())
+	}
+	return s
+}
@@ -0,0 +1,53 @@
+package cron
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseWithDiagnostics(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		want []string
+	}{
+		{"*/15 9-17 * * MON-FRI", nil},
+		{"@daily", nil},
+		{"*/60 * * * *", []string{`minute field "*/60": the step is not smaller than the range, so only 0 is selected`}},
+		{"5/15 * * * *", []string{`minute field "5/15": the step is not smaller than the range, so only 5 is selected`}},
+		{"0 */30 * * *", []string{`hour field "*/30": the step is not smaller than the range, so only 0 is selected`}},
+		{"0 1-3/2 * * *", nil},
+		{"0 0 * JAN/12 *", []string{`month field "JAN/12": the step is not smaller than the range, so only JAN is selected`}},
+		{"*,5 * * * *", []string{`minute field "5": the list contains *, so this has no effect`}},
+		{"*/2,5 * * * *", nil},
+		{"0 22-2 * * *", []string{`hour field "22-2": the range wraps around the end of the field, selecting 0-2,22,23`}},
+		{"0 0 * * FRI-MON", []string{`day of week field "FRI-MON": the range wraps around the end of the field, selecting SUN,MON,FRI,SAT`}},
+		{
+			"*/90,3 0 30 2 *",
+			[]string{
+				`minute field "*/90": the step is not smaller than the range, so only 0 is selected`,
+				"the schedule never fires",
+			},
+		},
+	} {
+		s, warnings, err := ParseWithDiagnostics(tt.expr)
+		if err != nil {
+			t.Errorf("ParseWithDiagnostics(%q): %s", tt.expr, err)
+			continue
+		}
+		if want := mustParse(t, tt.expr); s != want {
+			t.Errorf("ParseWithDiagnostics(%q) = %s; want %s", tt.expr, s, want)
+		}
+		var got []string
+		for _, w := range warnings {
+			got = append(got, w.String())
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("ParseWithDiagnostics(%q): warnings (-got, +want):\n%s", tt.expr, diff)
+		}
+	}
+
+	if _, warnings, err := ParseWithDiagnostics("*/60 * * *"); err == nil || warnings != nil {
+		t.Errorf("ParseWithDiagnostics of an invalid expression: got %v, %v; want an error", warnings, err)
+	}
+}
@@ -0,0 +1,153 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A WeekSchedule is a Schedule with an extra field restricting it to some
+// ISO 8601 weeks of the year. Use ParseWeekSchedule to create a WeekSchedule.
+type WeekSchedule struct {
+	s     Schedule
+	weeks uint64 // bit i-1 is set for week i
+}
+
+// isoWeeks is the largest ISO week number.
+const isoWeeks = 53
+
+// ParseWeekSchedule parses a cron expression with a sixth field giving the
+// ISO 8601 weeks of the year (1-53) in which the schedule fires. The first
+// five fields are as described by Parse (except that named schedules cannot
+// be used), and the sixth field accepts the same symbols. For example,
+//
+//	0 6 * * MON 2-53/2
+//
+// fires at 06:00 on Monday of every even-numbered week.
+//
+// In ISO 8601, weeks start on Monday, and week 1 of a year is the week
+// containing its first Thursday, so the first few days of January may belong
+// to the last week of the previous year (see time.Time.ISOWeek). Only some
+// years have a week 53, so a schedule using "*/2" fires in consecutive weeks
+// around the end of those years; weeks are not counted continuously across
+// years.
+func ParseWeekSchedule(expr string) (WeekSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 6 {
+		return WeekSchedule{}, fmt.Errorf("wrong number of fields in schedule %q (expected 6)", expr)
+	}
+	s, err := Parse(strings.Join(fields[:5], " "))
+	if err != nil {
+		return WeekSchedule{}, err
+	}
+	w := WeekSchedule{s: s}
+	for _, part := range strings.Split(fields[5], ",") {
+		weeks, err := parseWeekPart(part)
+		if err != nil {
+			return WeekSchedule{}, err
+		}
+		w.weeks |= weeks
+	}
+	return w, nil
+}
+
+func parseWeekPart(part string) (uint64, error) {
+	step := 1
+	incParts := strings.SplitN(part, "/", 2)
+	if len(incParts) > 1 {
+		var err error
+		step, err = strconv.Atoi(incParts[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid step increment: %q", incParts[1])
+		}
+		if step < 1 {
+			return 0, fmt.Errorf("invalid step increment %d (must be at least 1)", step)
+		}
+	}
+	var start, end int // inclusive
+	if incParts[0] == "*" {
+		start, end = 1, isoWeeks
+	} else if rangeParts := strings.SplitN(incParts[0], "-", 2); len(rangeParts) == 2 {
+		var err error
+		if start, err = parseWeekValue(rangeParts[0]); err != nil {
+			return 0, err
+		}
+		if end, err = parseWeekValue(rangeParts[1]); err != nil {
+			return 0, err
+		}
+		if start == end {
+			return 0, fmt.Errorf("bad range %q -- start and end must be different", incParts[0])
+		}
+	} else {
+		var err error
+		if start, err = parseWeekValue(incParts[0]); err != nil {
+			return 0, err
+		}
+		end = start
+	}
+
+	var weeks uint64
+	for i, week := 0, start; ; i++ {
+		if i%step == 0 {
+			weeks |= 1 << uint(week-1)
+		}
+		if week == end {
+			break
+		}
+		week++
+		if week > isoWeeks {
+			week = 1
+		}
+	}
+	return weeks, nil
+}
+
+func parseWeekValue(val string) (int, error) {
+	n, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q for the week field", val)
+	}
+	if n < 1 || n > isoWeeks {
+		return 0, fmt.Errorf("invalid value %d for the week field", n)
+	}
+	return n, nil
+}
+
+// Schedule returns the Schedule given by the first five fields of w.
+func (w WeekSchedule) Schedule() Schedule {
+	return w.s
+}
+
+// Matches reports whether w fires at the minute containing t.
+func (w WeekSchedule) Matches(t time.Time) bool {
+	return w.matchesWeek(t) && w.s.Matches(t)
+}
+
+func (w WeekSchedule) matchesWeek(t time.Time) bool {
+	_, week := t.ISOWeek()
+	return w.weeks&(1<<uint(week-1)) != 0
+}
+
+// Next gives the smallest time greater than t when w is satisfied. If w
+// never fires (as with "0 0 * JUN MON 1", since no Monday in June is in
+// week 1), Next returns the zero Time. Next panics if w's Schedule is not
+// valid.
+func (w WeekSchedule) Next(t time.Time) time.Time {
+	// The Gregorian calendar, and so the ISO week calendar, repeats every
+	// 400 years, so if w fires at all it fires within 400 years of t.
+	limit := t.AddDate(400, 0, 0)
+	for {
+		t = w.s.Next(t)
+		if t.IsZero() || t.After(limit) {
+			return time.Time{}
+		}
+		if w.matchesWeek(t) {
+			return t
+		}
+		// Skip to the end of the week.
+		year, month, day := t.Date()
+		daysLeft := 7 - (int(t.Weekday())+6)%7
+		t = time.Date(year, month, day+daysLeft, 0, 0, 0, 0, t.Location()).Add(-time.Nanosecond)
+	}
+}
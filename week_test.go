@@ -0,0 +1,104 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var _ Recurrence = WeekSchedule{}
+
+func TestParseWeekSchedule(t *testing.T) {
+	for _, tt := range []struct {
+		expr  string
+		weeks []int
+	}{
+		{"0 6 * * MON *", nil},
+		{"0 6 * * MON 2-53/2", []int{2, 4, 50, 52}},
+		{"0 6 * * MON 1,10-12", []int{1, 10, 11, 12}},
+		{"0 6 * * MON 52-2", []int{1, 2, 52, 53}},
+	} {
+		w, err := ParseWeekSchedule(tt.expr)
+		if err != nil {
+			t.Errorf("ParseWeekSchedule(%q): %s", tt.expr, err)
+			continue
+		}
+		if tt.weeks == nil {
+			if w.weeks != 1<<isoWeeks-1 {
+				t.Errorf("ParseWeekSchedule(%q) has weeks %b; want all", tt.expr, w.weeks)
+			}
+			continue
+		}
+		for _, week := range tt.weeks {
+			if w.weeks&(1<<uint(week-1)) == 0 {
+				t.Errorf("ParseWeekSchedule(%q) does not include week %d", tt.expr, week)
+			}
+		}
+		if w.Schedule() != mustParse(t, "0 6 * * MON") {
+			t.Errorf("ParseWeekSchedule(%q).Schedule() = %q", tt.expr, w.Schedule())
+		}
+	}
+
+	for _, expr := range []string{
+		"0 6 * * MON",
+		"@daily *",
+		"0 6 * * MON 0",
+		"0 6 * * MON 54",
+		"0 6 * * MON 3-3",
+		"0 6 * * MON */0",
+		"0 6 * * MON x",
+	} {
+		if _, err := ParseWeekSchedule(expr); err == nil {
+			t.Errorf("ParseWeekSchedule(%q) succeeded; want error", expr)
+		}
+	}
+}
+
+func TestWeekScheduleNext(t *testing.T) {
+	w, err := ParseWeekSchedule("0 6 * * MON 2-53/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026 has 53 weeks; week 1 of 2027 begins on January 4.
+	tm := time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)
+	var got []time.Time
+	for i := 0; i < 4; i++ {
+		tm = w.Next(tm)
+		got = append(got, tm)
+	}
+	want := []time.Time{
+		time.Date(2026, 12, 7, 6, 0, 0, 0, time.UTC),  // week 50
+		time.Date(2026, 12, 21, 6, 0, 0, 0, time.UTC), // week 52
+		time.Date(2027, 1, 11, 6, 0, 0, 0, time.UTC),  // week 2
+		time.Date(2027, 1, 25, 6, 0, 0, 0, time.UTC),  // week 4
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Next (-got, +want):\n%s", diff)
+	}
+	for _, tm := range want {
+		if !w.Matches(tm) {
+			t.Errorf("Matches(%s) = false", tm)
+		}
+		if w.Matches(tm.AddDate(0, 0, 7)) {
+			t.Errorf("Matches(%s) = true", tm.AddDate(0, 0, 7))
+		}
+	}
+}
+
+func TestWeekScheduleNeverFires(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, expr := range []string{
+		"0 0 * JUN MON 1", // no Monday in June is in week 1
+		"0 0 1 JAN * 10",
+		"0 0 30 2 * *",
+	} {
+		w, err := ParseWeekSchedule(expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := w.Next(from); !got.IsZero() {
+			t.Errorf("%q.Next(%s) = %s; want the zero Time", expr, from, got)
+		}
+	}
+}
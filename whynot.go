@@ -0,0 +1,75 @@
+package cron
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// A MismatchError explains why a Schedule does not fire at a particular time.
+// It is returned by Schedule.WhyNot.
+type MismatchError struct {
+	Time   time.Time
+	Fields []FieldMismatch
+}
+
+// A FieldMismatch describes a single field of a schedule that rejects a time.
+type FieldMismatch struct {
+	// Field is the name of the field: "minute", "hour", "day of month",
+	// "month", or "day of week".
+	Field string
+	// Value is the field's value for the time. As in cron expressions,
+	// days of the month and months start at 1 and days of the week start
+	// at 0 (Sunday).
+	Value int
+	// Allowed lists the values permitted by the schedule, in increasing
+	// order.
+	Allowed []int
+
+	fieldIndex int
+}
+
+func (e *MismatchError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (f FieldMismatch) String() string {
+	return fmt.Sprintf("%s %s not in {%s}",
+		f.Field, describeValue(f.Value, f.fieldIndex), formatList(f.Allowed, f.fieldIndex, formatStyle{}))
+}
+
+// WhyNot explains why s does not fire at the minute containing t. If s fires
+// at t, WhyNot returns nil. Otherwise it returns a *MismatchError listing
+// every field of s that rejects t; for example,
+//
+//	hour 14 not in {3}; day of week Tuesday not in {WED}
+func (s Schedule) WhyNot(t time.Time) error {
+	vals := [...]int{
+		0: t.Minute(),
+		1: t.Hour(),
+		2: t.Day(),
+		3: int(t.Month()),
+		4: int(t.Weekday()),
+	}
+	var e MismatchError
+	for i, v := range vals {
+		if s.isSet(fieldOffsets[i] + v - fieldMin(i)) {
+			continue
+		}
+		e.Fields = append(e.Fields, FieldMismatch{
+			Field:      fieldNames[i],
+			Value:      v,
+			Allowed:    s.fieldValues(i),
+			fieldIndex: i,
+		})
+	}
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	e.Time = t
+	return &e
+}
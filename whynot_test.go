@@ -0,0 +1,47 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWhyNot(t *testing.T) {
+	for _, tt := range []struct {
+		expr string
+		t    time.Time
+		want string // empty if the schedule matches
+	}{
+		{"* * * * *", time.Date(2014, 1, 1, 14, 30, 0, 0, time.UTC), ""},
+		{"0 3 * * Wed", time.Date(2014, 1, 1, 3, 0, 59, 0, time.UTC), ""},
+		{
+			"0 3 * * Wed",
+			time.Date(2014, 1, 7, 14, 0, 0, 0, time.UTC),
+			"hour 14 not in {3}; day of week Tuesday not in {WED}",
+		},
+		{
+			"*/15 9-17 1 JAN-MAR MON-FRI",
+			time.Date(2014, 6, 2, 20, 5, 0, 0, time.UTC),
+			"minute 5 not in {0,15,30,45}; hour 20 not in {9-17}; " +
+				"day of month 2 not in {1}; month June not in {JAN-MAR}",
+		},
+	} {
+		s := mustParse(t, tt.expr)
+		err := s.WhyNot(tt.t)
+		if got := s.Matches(tt.t); got != (tt.want == "") {
+			t.Errorf("Parse(%q).Matches(%s) = %t", tt.expr, tt.t, got)
+		}
+		if tt.want == "" {
+			if err != nil {
+				t.Errorf("Parse(%q).WhyNot(%s): got %q; want nil", tt.expr, tt.t, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Parse(%q).WhyNot(%s) = nil; want %q", tt.expr, tt.t, tt.want)
+			continue
+		}
+		if err.Error() != tt.want {
+			t.Errorf("Parse(%q).WhyNot(%s):\ngot  %q\nwant %q", tt.expr, tt.t, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package cron
+
+import "time"
+
+// A ZonedSchedule is a Schedule whose fields are interpreted as wall clock
+// times in a particular location, regardless of the location of the times
+// passed to Next. Use Schedule.In to create a ZonedSchedule.
+//
+// Around daylight saving time changes, some wall clock times do not occur
+// and others occur twice. By default, a ZonedSchedule does not fire at wall
+// clock times skipped by a change (see GapPolicy) and fires at both instants
+// of a wall clock time that is repeated (see FoldPolicy).
+type ZonedSchedule struct {
+	s    Schedule
+	loc  *time.Location
+	gap  GapPolicy
+	fold FoldPolicy
+}
+
+// In returns a ZonedSchedule that fires at s's wall clock times in loc.
+// In panics if loc is nil.
+func (s Schedule) In(loc *time.Location) ZonedSchedule {
+	if loc == nil {
+		panic("cron: nil Location in Schedule.In")
+	}
+	return ZonedSchedule{s: s, loc: loc}
+}
+
+// A GapPolicy says what a ZonedSchedule does when a wall clock time at which
+// it fires is skipped because the clocks go forward, as at 02:30 on the day
+// daylight saving time begins in the United States.
+type GapPolicy int
+
+const (
+	// GapSkip ignores skipped wall clock times.
+	GapSkip GapPolicy = iota
+	// GapFireAfter fires at the first instant after the skipped wall clock
+	// times (03:00 in the example above), as Vixie cron does. The
+	// schedule fires once at that instant no matter how many of its wall
+	// clock times are skipped.
+	GapFireAfter
+)
+
+// WithGapPolicy returns a copy of z that handles skipped wall clock times
+// according to p.
+func (z ZonedSchedule) WithGapPolicy(p GapPolicy) ZonedSchedule {
+	z.gap = p
+	return z
+}
+
+// A FoldPolicy says what a ZonedSchedule does when a wall clock time at which
+// it fires occurs twice because the clocks go back, as at 01:30 on the day
+// daylight saving time ends in the United States.
+type FoldPolicy int
+
+const (
+	// FoldBoth fires at both instants with the wall clock time.
+	FoldBoth FoldPolicy = iota
+	// FoldFirst fires only at the first instant, before the clocks go
+	// back.
+	FoldFirst
+	// FoldSecond fires only at the second instant, after the clocks go
+	// back.
+	FoldSecond
+)
+
+// WithFoldPolicy returns a copy of z that handles repeated wall clock times
+// according to p.
+func (z ZonedSchedule) WithFoldPolicy(p FoldPolicy) ZonedSchedule {
+	z.fold = p
+	return z
+}
+
+// maxZoneShift bounds the size of any change in a location's UTC offset
+// that ZonedSchedule handles exactly. Real daylight saving time changes are
+// at most two hours.
+const maxZoneShift = 3 * time.Hour
+
+// Next returns the earliest time after t at which z fires, in t's location.
+// If z's Schedule never fires, Next returns the zero Time. Next panics if z's
+// Schedule is not valid.
+func (z ZonedSchedule) Next(t time.Time) time.Time {
+	if err := z.s.Validate(); err != nil {
+		panic("Next() called on invalid schedule: " + err.Error())
+	}
+	if z.s.normalize() == (Schedule{}) {
+		return time.Time{}
+	}
+	// The search runs over wall clock times, represented as UTC times so
+	// that they are evenly spaced. An occurrence after t has a wall clock
+	// time after t plus the smaller of t's UTC offset and the offset in
+	// effect shortly after t (which is smaller if the clocks go back).
+	_, off := t.In(z.loc).Zone()
+	_, off1 := t.Add(maxZoneShift).In(z.loc).Zone()
+	steady := off1 == off
+	maxOff := off
+	if off1 < off {
+		off = off1
+	} else {
+		maxOff = off1
+	}
+	wall := t.UTC().Add(time.Duration(off) * time.Second)
+	// When the clocks go back, the second instants of the repeated wall
+	// clock times come after the first instants of later ones, so the
+	// search continues until no later wall clock time can occur before
+	// the best occurrence found.
+	var best time.Time
+	for {
+		wall = z.s.Next(wall)
+		if !best.IsZero() && !wall.Add(-time.Duration(maxOff)*time.Second).Before(best) {
+			return best.In(t.Location())
+		}
+		if next := wall.Add(-time.Duration(off) * time.Second); steady && z.fold == FoldBoth && !next.After(t.Add(maxZoneShift)) {
+			// The offset doesn't change between t and next, so next
+			// is the first time with this wall clock time.
+			return next.In(t.Location())
+		}
+		for _, next := range z.occurrences(wall, off) {
+			if next.After(t) && (best.IsZero() || next.Before(best)) {
+				best = next
+			}
+		}
+	}
+}
+
+// Matches reports whether z fires at the minute containing t. Unlike
+// Schedule.Matches, it considers the instant t rather than t's wall clock
+// time, and applies z's GapPolicy and FoldPolicy: for example, with
+// FoldFirst, "30 1 * * *" in America/New_York matches 01:30 EDT but not
+// 01:30 EST on the day daylight saving time ends, and with GapFireAfter,
+// "30 2 * * *" matches 03:00 EDT on the day it begins.
+func (z ZonedSchedule) Matches(t time.Time) bool {
+	t = t.Truncate(time.Minute)
+	_, off := t.In(z.loc).Zone()
+	wall := t.UTC().Add(time.Duration(off) * time.Second)
+	if z.s.Matches(wall) {
+		for _, t1 := range z.occurrences(wall, off) {
+			if t1.Equal(t) {
+				return true
+			}
+		}
+	}
+	if z.gap == GapFireAfter {
+		// If the clocks went forward at t, check the skipped wall
+		// clock times.
+		if _, prev := t.Add(-time.Second).In(z.loc).Zone(); prev < off {
+			skipped := t.UTC().Add(time.Duration(prev) * time.Second)
+			return z.s.Next(skipped.Add(-time.Nanosecond)).Before(wall)
+		}
+	}
+	return false
+}
+
+// occurrences returns, in order, the times at which z fires for the wall
+// clock time wall (a UTC time) according to its GapPolicy and FoldPolicy.
+// The UTC offset off should be in effect near the wall clock time.
+func (z ZonedSchedule) occurrences(wall time.Time, off int) []time.Time {
+	var buf [2]time.Time
+	ts := z.instants(buf[:0], wall, off)
+	switch {
+	case len(ts) == 0 && z.gap == GapFireAfter:
+		ts = append(ts, z.gapEnd(wall))
+	case len(ts) == 2 && z.fold == FoldFirst:
+		ts = ts[:1]
+	case len(ts) == 2 && z.fold == FoldSecond:
+		ts = ts[1:]
+	}
+	return ts
+}
+
+// instants appends to ts, in order, the times at which the wall clock in z's
+// location reads wall (a UTC time) and returns the extended slice. There are
+// none if the wall clock time is skipped by a change in the UTC offset and
+// two if it is repeated. The UTC offset off should be in effect near the
+// wall clock time.
+func (z ZonedSchedule) instants(ts []time.Time, wall time.Time, off int) []time.Time {
+	// Find the offsets in effect shortly before and after the wall clock
+	// time. Usually they are the same, and the wall clock time occurs
+	// exactly once.
+	near := wall.Add(-time.Duration(off) * time.Second)
+	_, before := near.Add(-maxZoneShift).In(z.loc).Zone()
+	_, after := near.Add(maxZoneShift).In(z.loc).Zone()
+	if before == after {
+		return append(ts, wall.Add(-time.Duration(before)*time.Second).In(z.loc))
+	}
+	// The larger offset gives the earlier time.
+	offs := [2]int{before, after}
+	if before < after {
+		offs = [2]int{after, before}
+	}
+	for _, off := range offs {
+		t := wall.Add(-time.Duration(off) * time.Second).In(z.loc)
+		if _, off1 := t.Zone(); off1 == off {
+			ts = append(ts, t)
+		}
+	}
+	return ts
+}
+
+// gapEnd returns the instant at which the clocks in z's location go forward
+// past the skipped wall clock time wall (a UTC time).
+func (z ZonedSchedule) gapEnd(wall time.Time) time.Time {
+	// Before the change, the wall clock is behind wall; after it, the wall
+	// clock is ahead. Search for the change to the nearest second.
+	guess := z.guess(wall)
+	lo := guess.Add(-maxZoneShift).Unix()
+	hi := guess.Add(maxZoneShift).Unix()
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		_, off := time.Unix(mid, 0).In(z.loc).Zone()
+		if mid+int64(off) < wall.Unix() {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return time.Unix(hi, 0).In(z.loc)
+}
+
+// guess returns a time near the instant at which the wall clock in z's
+// location reads wall (a UTC time).
+func (z ZonedSchedule) guess(wall time.Time) time.Time {
+	year, month, day := wall.Date()
+	hour, min, sec := wall.Clock()
+	return time.Date(year, month, day, hour, min, sec, wall.Nanosecond(), z.loc)
+}
+
+// NextInZones returns, for each location in locs, the earliest time after t
+// at which s fires in that location, as by s.In(loc).Next. Each result is
+// in the corresponding location.
+func NextInZones(s Schedule, t time.Time, locs []*time.Location) []time.Time {
+	next := make([]time.Time, len(locs))
+	for i, loc := range locs {
+		next[i] = s.In(loc).Next(t.In(loc))
+	}
+	return next
+}
+
+// EarliestInZones returns the earliest time after t at which s fires in any
+// of the locations in locs, along with that location. If s fires at the same
+// instant in several locations, the first of them in locs is returned. If
+// locs is empty, EarliestInZones returns the zero Time and a nil Location.
+func EarliestInZones(s Schedule, t time.Time, locs []*time.Location) (time.Time, *time.Location) {
+	var earliest time.Time
+	var loc *time.Location
+	for i, next := range NextInZones(s, t, locs) {
+		if loc == nil || next.Before(earliest) {
+			earliest, loc = next, locs[i]
+		}
+	}
+	return earliest, loc
+}
@@ -0,0 +1,269 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var _ Recurrence = ZonedSchedule{}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestZonedScheduleNext(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	kolkata := mustLoadLocation(t, "Asia/Kolkata")
+	for _, tt := range []struct {
+		expr string
+		loc  *time.Location
+		t    time.Time
+		want []time.Time
+	}{
+		{
+			// The argument's location doesn't matter; the result is in
+			// the same location.
+			"0 9 * * *",
+			ny,
+			time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+			[]time.Time{
+				time.Date(2026, 6, 1, 13, 0, 0, 0, time.UTC),
+				time.Date(2026, 6, 2, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// Hours begin on the half hour in UTC.
+			"0 * * * *",
+			kolkata,
+			time.Date(2026, 6, 1, 10, 10, 0, 0, kolkata),
+			[]time.Time{
+				time.Date(2026, 6, 1, 11, 0, 0, 0, kolkata),
+				time.Date(2026, 6, 1, 12, 0, 0, 0, kolkata),
+			},
+		},
+		{
+			// 02:30 doesn't occur on March 8.
+			"30 2 * * *",
+			ny,
+			time.Date(2026, 3, 7, 12, 0, 0, 0, ny),
+			[]time.Time{
+				time.Date(2026, 3, 9, 2, 30, 0, 0, ny),
+				time.Date(2026, 3, 10, 2, 30, 0, 0, ny),
+			},
+		},
+		{
+			"*/30 * * * *",
+			ny,
+			time.Date(2026, 3, 8, 1, 0, 0, 0, ny),
+			[]time.Time{
+				time.Date(2026, 3, 8, 1, 30, 0, 0, ny),
+				time.Date(2026, 3, 8, 3, 0, 0, 0, ny),
+			},
+		},
+		{
+			// 01:30 occurs twice on November 1.
+			"30 1 * * *",
+			ny,
+			time.Date(2026, 11, 1, 0, 0, 0, 0, ny),
+			[]time.Time{
+				time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC),
+				time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC),
+				time.Date(2026, 11, 2, 6, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			// Within the repeated hour, each instant comes before the
+			// second instant of the previous wall clock time.
+			"*/30 * * * *",
+			ny,
+			time.Date(2026, 11, 1, 0, 59, 0, 0, ny),
+			[]time.Time{
+				time.Date(2026, 11, 1, 5, 0, 0, 0, time.UTC),
+				time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC),
+				time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC),
+				time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC),
+				time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// An occurrence in the repeated hour can follow t even
+			// though its wall clock time is earlier than t's.
+			"15 1 * * *",
+			ny,
+			time.Date(2026, 11, 1, 5, 45, 0, 0, time.UTC), // 01:45 EDT
+			[]time.Time{
+				time.Date(2026, 11, 1, 6, 15, 0, 0, time.UTC), // 01:15 EST
+				time.Date(2026, 11, 2, 6, 15, 0, 0, time.UTC),
+			},
+		},
+	} {
+		z := mustParse(t, tt.expr).In(tt.loc)
+		var got []time.Time
+		tm := tt.t
+		for range tt.want {
+			tm = z.Next(tm)
+			got = append(got, tm)
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("Parse(%q).In(%s) from %s (-got, +want):\n%s", tt.expr, tt.loc, tt.t, diff)
+		}
+		if loc := got[0].Location(); loc != tt.t.Location() {
+			t.Errorf("Parse(%q).In(%s).Next returned a time in %s; want %s", tt.expr, tt.loc, loc, tt.t.Location())
+		}
+	}
+}
+
+func TestZonedScheduleGapPolicy(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	for _, tt := range []struct {
+		expr string
+		want []time.Time
+	}{
+		{
+			"30 2 * * *",
+			[]time.Time{
+				time.Date(2026, 3, 8, 3, 0, 0, 0, ny),
+				time.Date(2026, 3, 9, 2, 30, 0, 0, ny),
+			},
+		},
+		{
+			// The schedule fires once for all the skipped times,
+			// including 03:00 itself.
+			"*/15 2,3 * * *",
+			[]time.Time{
+				time.Date(2026, 3, 8, 3, 0, 0, 0, ny),
+				time.Date(2026, 3, 8, 3, 15, 0, 0, ny),
+			},
+		},
+	} {
+		z := mustParse(t, tt.expr).In(ny).WithGapPolicy(GapFireAfter)
+		var got []time.Time
+		tm := time.Date(2026, 3, 7, 12, 0, 0, 0, ny)
+		for range tt.want {
+			tm = z.Next(tm)
+			got = append(got, tm)
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("Parse(%q).In(%s) with GapFireAfter (-got, +want):\n%s", tt.expr, ny, diff)
+		}
+	}
+}
+
+func TestZonedScheduleFoldPolicy(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	var (
+		edt  = time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC) // 01:30 EDT
+		est  = time.Date(2026, 11, 1, 6, 30, 0, 0, time.UTC) // 01:30 EST
+		next = time.Date(2026, 11, 2, 6, 30, 0, 0, time.UTC)
+	)
+	for _, tt := range []struct {
+		p    FoldPolicy
+		want []time.Time
+	}{
+		{FoldBoth, []time.Time{edt, est, next}},
+		{FoldFirst, []time.Time{edt, next, next.AddDate(0, 0, 1)}},
+		{FoldSecond, []time.Time{est, next, next.AddDate(0, 0, 1)}},
+	} {
+		z := mustParse(t, "30 1 * * *").In(ny).WithFoldPolicy(tt.p)
+		var got []time.Time
+		tm := time.Date(2026, 10, 31, 12, 0, 0, 0, ny)
+		for range tt.want {
+			tm = z.Next(tm)
+			got = append(got, tm)
+		}
+		if diff := cmp.Diff(got, tt.want); diff != "" {
+			t.Errorf("fold policy %d (-got, +want):\n%s", tt.p, diff)
+		}
+	}
+
+	// With FoldFirst, a schedule firing every 20 minutes skips the
+	// repeated hour entirely.
+	z := mustParse(t, "*/20 * * * *").In(ny).WithFoldPolicy(FoldFirst)
+	tm := time.Date(2026, 11, 1, 5, 40, 0, 0, time.UTC) // 01:40 EDT
+	if got, want := z.Next(tm), time.Date(2026, 11, 1, 7, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Next(%s) = %s; want %s (02:00 EST)", tm, got, want)
+	}
+}
+
+func TestNextInZones(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	london := mustLoadLocation(t, "Europe/London")
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	locs := []*time.Location{ny, london, tokyo}
+	s := mustParse(t, "0 9 * * MON-FRI")
+	tm := time.Date(2026, 6, 5, 10, 0, 0, 0, time.UTC) // Friday
+
+	got := NextInZones(s, tm, locs)
+	want := []time.Time{
+		time.Date(2026, 6, 5, 9, 0, 0, 0, ny),
+		time.Date(2026, 6, 8, 9, 0, 0, 0, london),
+		time.Date(2026, 6, 8, 9, 0, 0, 0, tokyo),
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("NextInZones (-got, +want):\n%s", diff)
+	}
+	for i, next := range got {
+		if next.Location() != locs[i] {
+			t.Errorf("NextInZones returned %s in %s; want %s", next, next.Location(), locs[i])
+		}
+	}
+
+	earliest, loc := EarliestInZones(s, tm, locs)
+	if !earliest.Equal(want[0]) || loc != ny {
+		t.Errorf("EarliestInZones = %s, %s; want %s, %s", earliest, loc, want[0], ny)
+	}
+	// Tokyo's Monday morning comes first after the New York job.
+	earliest, loc = EarliestInZones(s, want[0], locs)
+	if !earliest.Equal(want[2]) || loc != tokyo {
+		t.Errorf("EarliestInZones = %s, %s; want %s, %s", earliest, loc, want[2], tokyo)
+	}
+	if earliest, loc := EarliestInZones(s, tm, nil); !earliest.IsZero() || loc != nil {
+		t.Errorf("EarliestInZones with no locations = %s, %v; want zero values", earliest, loc)
+	}
+}
+
+func TestZonedScheduleMatches(t *testing.T) {
+	ny := mustLoadLocation(t, "America/New_York")
+	var (
+		edt = time.Date(2026, 11, 1, 5, 30, 20, 0, time.UTC) // 01:30:20 EDT
+		est = time.Date(2026, 11, 1, 6, 30, 20, 0, time.UTC) // 01:30:20 EST
+		// The clocks go forward from 02:00 EST to 03:00 EDT.
+		gapEnd = time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC)
+	)
+	fold := mustParse(t, "30 1 * * *").In(ny)
+	gap := mustParse(t, "30 2 * * *").In(ny)
+	for _, tt := range []struct {
+		name string
+		z    ZonedSchedule
+		t    time.Time
+		want bool
+	}{
+		{"FoldBoth, first", fold, edt, true},
+		{"FoldBoth, second", fold, est, true},
+		{"FoldFirst, first", fold.WithFoldPolicy(FoldFirst), edt, true},
+		{"FoldFirst, second", fold.WithFoldPolicy(FoldFirst), est, false},
+		{"FoldSecond, first", fold.WithFoldPolicy(FoldSecond), edt, false},
+		{"FoldSecond, second", fold.WithFoldPolicy(FoldSecond), est, true},
+		{"other minute", fold, edt.Add(time.Minute), false},
+		{"GapSkip", gap, gapEnd, false},
+		{"GapFireAfter", gap.WithGapPolicy(GapFireAfter), gapEnd, true},
+		{"GapFireAfter, next minute", gap.WithGapPolicy(GapFireAfter), gapEnd.Add(time.Minute), false},
+		{"GapFireAfter, unmatched gap", mustParse(t, "30 3 * * *").In(ny).WithGapPolicy(GapFireAfter), gapEnd, false},
+	} {
+		if got := tt.z.Matches(tt.t); got != tt.want {
+			t.Errorf("%s: Matches(%s) = %t; want %t", tt.name, tt.t, got, tt.want)
+		}
+		// Matches agrees with Next.
+		m := tt.t.Truncate(time.Minute)
+		if got := tt.z.Next(m.Add(-time.Nanosecond)).Equal(m); got != tt.want {
+			t.Errorf("%s: Next(%s) == %s is %t; want %t", tt.name, m.Add(-time.Nanosecond), m, got, tt.want)
+		}
+	}
+}